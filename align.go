@@ -117,3 +117,71 @@ func Align(plots [][]*Plot, t draw.Tiles, dc draw.Canvas) [][]draw.Canvas {
 	}
 	return o
 }
+
+// DrawSharedLegend draws the plots to dc, tiled as by
+// Align, with a single legend in place of their own.  The
+// shared legend has the style and placement of l, and its
+// entries are those of l followed by those of the Legend of
+// each plot whose names are not already in the legend, so
+// that plots with identical series share one legend entry
+// per series.  Space for the shared legend is reserved
+// along the side of dc given by l.Left, or by l.Top if l
+// is Horizontal, and the plots are tiled in the rest of
+// dc.  The legends of the plots are not drawn.
+func DrawSharedLegend(plots [][]*Plot, t draw.Tiles, l Legend, dc draw.Canvas) {
+	names := make(map[string]bool)
+	for _, e := range l.entries {
+		names[e.text] = true
+	}
+	l.entries = append([]legendEntry(nil), l.entries...)
+	for _, row := range plots {
+		for _, p := range row {
+			if p == nil {
+				continue
+			}
+			for _, e := range p.Legend.entries {
+				if !names[e.text] {
+					names[e.text] = true
+					l.entries = append(l.entries, e)
+				}
+			}
+		}
+	}
+
+	plotC := dc
+	legendC := dc
+	if len(l.entries) != 0 {
+		size := l.Rectangle(dc).Size()
+		gap := l.entryHeight() / 2
+		switch {
+		case l.Horizontal && l.Top:
+			plotC.Max.Y -= size.Y + gap
+			legendC.Min.Y = plotC.Max.Y + gap
+		case l.Horizontal:
+			plotC.Min.Y += size.Y + gap
+			legendC.Max.Y = plotC.Min.Y - gap
+		case l.Left:
+			plotC.Min.X += size.X + gap
+			legendC.Max.X = plotC.Min.X - gap
+		default:
+			plotC.Max.X -= size.X + gap
+			legendC.Min.X = plotC.Max.X + gap
+		}
+	}
+
+	cs := Align(plots, t, plotC)
+	for j, row := range plots {
+		for i, p := range row {
+			if p == nil {
+				continue
+			}
+			entries, legends := p.Legend.entries, p.Legends
+			p.Legend.entries, p.Legends = nil, nil
+			p.Draw(cs[j][i])
+			p.Legend.entries, p.Legends = entries, legends
+		}
+	}
+	if len(l.entries) != 0 {
+		l.Draw(legendC)
+	}
+}
@@ -5,13 +5,17 @@
 package plot
 
 import (
+	"fmt"
+	"image/color"
 	"math"
 	"os"
+	"reflect"
 	"testing"
 
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
 	"gonum.org/v1/plot/vg/vgimg"
 )
 
@@ -95,3 +99,94 @@ func ExampleAlign() {
 func TestAlign(t *testing.T) {
 	cmpimg.CheckPlot(ExampleAlign, t, "align.png")
 }
+
+func ExampleDrawSharedLegend() {
+	red := exampleThumbnailer{Color: color.NRGBA{R: 255, A: 255}}
+	blue := exampleThumbnailer{Color: color.NRGBA{B: 255, A: 255}}
+
+	const rows, cols = 2, 2
+	plots := make([][]*Plot, rows)
+	for j := range plots {
+		plots[j] = make([]*Plot, cols)
+		for i := range plots[j] {
+			p, err := New()
+			if err != nil {
+				panic(err)
+			}
+			p.Title.Text = fmt.Sprintf("Plot %d", j*cols+i+1)
+			p.X.Min, p.X.Max = 0, 10
+			p.Y.Min, p.Y.Max = 0, 1
+
+			// Each plot has the same series.
+			p.Legend.Add("measured", red)
+			p.Legend.Add("expected", blue)
+			plots[j][i] = p
+		}
+	}
+
+	l, err := NewLegend()
+	if err != nil {
+		panic(err)
+	}
+	l.Top = true
+
+	img := vgimg.New(vg.Points(300), vg.Points(200))
+	dc := draw.New(img)
+	t := draw.Tiles{
+		Rows: rows,
+		Cols: cols,
+		PadX: vg.Millimeter,
+		PadY: vg.Millimeter,
+	}
+	DrawSharedLegend(plots, t, l, dc)
+
+	w, err := os.Create("testdata/drawSharedLegend.png")
+	if err != nil {
+		panic(err)
+	}
+	defer w.Close()
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err := png.WriteTo(w); err != nil {
+		panic(err)
+	}
+}
+
+func TestDrawSharedLegend(t *testing.T) {
+	cmpimg.CheckPlot(ExampleDrawSharedLegend, t, "drawSharedLegend.png")
+
+	red := exampleThumbnailer{Color: color.NRGBA{R: 255, A: 255}}
+	plots := [][]*Plot{make([]*Plot, 2)}
+	for i := range plots[0] {
+		p, err := New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.HideAxes()
+		p.Legend.Add("shared", red)
+		plots[0][i] = p
+	}
+	plots[0][1].Legend.Add("second only", red)
+
+	l, err := NewLegend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var r recorder.Canvas
+	DrawSharedLegend(plots, draw.Tiles{Rows: 1, Cols: 2}, l, draw.NewCanvas(&r, 300, 100))
+
+	counts := make(map[string]int)
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.FillString); ok {
+			counts[s.String]++
+		}
+	}
+	want := map[string]int{"shared": 1, "second only": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("unexpected legend texts drawn: got:%v want:%v", counts, want)
+	}
+	for i, p := range plots[0] {
+		if len(p.Legend.entries) != i+1 {
+			t.Errorf("legend of plot %d not restored: got %d entries", i, len(p.Legend.entries))
+		}
+	}
+}
@@ -5,11 +5,15 @@
 package plot
 
 import (
+	"fmt"
 	"image/color"
 	"math"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
@@ -28,6 +32,19 @@ type Normalizer interface {
 	Normalize(min, max, x float64) float64
 }
 
+// DomainNormalizer is implemented by Normalizers that
+// are defined for only some values in the data coordinate
+// system, such as LogScale, which is defined only for
+// positive values.  The ranges of the axes of a plot are
+// not extended to values outside of the domain of their
+// Scale when data is added.
+type DomainNormalizer interface {
+	Normalizer
+
+	// InDomain returns whether x can be normalized.
+	InDomain(x float64) bool
+}
+
 // An Axis represents either a horizontal or vertical
 // axis of a plot.
 type Axis struct {
@@ -42,11 +59,28 @@ type Axis struct {
 		// TextStyle is the style of the axis label text.
 		// For the vertical axis, one quarter turn
 		// counterclockwise will be added to the label
-		// text before drawing.
+		// text before drawing, unless Horizontal is set.
 		draw.TextStyle
+
+		// Horizontal specifies that the label of the
+		// vertical axis is drawn without the added
+		// quarter turn, centered vertically to the left
+		// of the tick labels, and the width reserved for
+		// the axis is increased to hold it.  Short labels
+		// such as units may be easier to read this way.
+		// Horizontal has no effect on the horizontal
+		// axis.
+		Horizontal bool
+
+		// Padding is the distance between the label
+		// and the tick labels, in addition to the
+		// spacing given by the label text itself.
+		Padding vg.Length
 	}
 
-	// LineStyle is the style of the axis line.
+	// LineStyle is the style of the axis line.  Its
+	// color is independent of the colors of the tick
+	// marks and the tick labels.
 	draw.LineStyle
 
 	// Padding between the axis line and the data.  Having
@@ -56,22 +90,112 @@ type Axis struct {
 
 	Tick struct {
 		// Label is the TextStyle on the tick labels.
+		// The labels are drawn in Label.Color, which
+		// is independent of the tick mark color.
 		Label draw.TextStyle
 
+		// HideLabels specifies that the tick labels are
+		// not drawn.  The space for the labels is still
+		// reserved so that the data areas of plots with
+		// hidden labels align with those of plots that
+		// show them.
+		HideLabels bool
+
+		// Stacked specifies that the tick labels are
+		// written vertically, one character per line,
+		// instead of along a single line, which suits
+		// narrow axes and some scripts.  Label.Rotation
+		// is ignored for stacked labels.  The space
+		// reserved for the labels is that of the
+		// stacked text.
+		Stacked bool
+
+		// Wrap specifies that the tick labels of the
+		// horizontal axis are broken onto multiple lines
+		// at spaces, so that each label fits within the
+		// space between its tick and the neighboring major
+		// ticks, which suits long category names on a
+		// nominal axis.  A word that is wider than the
+		// space is not broken.  The space reserved for the
+		// labels is that of the wrapped text.  Wrap is
+		// ignored by the vertical axis, and for stacked or
+		// rotated labels.
+		Wrap bool
+
 		// LineStyle is the LineStyle of the tick lines.
 		draw.LineStyle
 
+		// ColorMap, if not nil, gives the colors of the
+		// tick marks and tick labels, which override the
+		// colors of LineStyle and Label.  Each tick is
+		// colored by the ColorMap at the tick's position
+		// along the axis, scaled so that the start of the
+		// axis takes the ColorMap's Min color and the end
+		// takes its Max color.  The ColorMap is not
+		// modified.
+		ColorMap palette.ColorMap
+
+		// Mirror specifies that the tick marks are also
+		// drawn, without labels, along the opposite edge
+		// of the data area, pointing into it, giving the
+		// plot a framed appearance.
+		Mirror bool
+
 		// Length is the length of a major tick mark.
 		// Minor tick marks are half of the length of major
 		// tick marks.
 		Length vg.Length
 
+		// LabelPadding is the padding between the tick
+		// labels and the tick marks.
+		LabelPadding vg.Length
+
+		// RelativeLength and RelativeLabelPadding, if
+		// non-zero, specify Length and LabelPadding as a
+		// fraction of the smaller dimension of the plot's
+		// data area.  They are computed each time the plot
+		// is drawn and override Length and LabelPadding
+		// respectively.
+		RelativeLength, RelativeLabelPadding float64
+
 		// Marker returns the tick marks.  Any tick marks
 		// returned by the Marker function that are not in
 		// range of the axis are not drawn.
 		Marker Ticker
+
+		// Groups are labels drawn on a second tier
+		// below the tick labels of the horizontal axis,
+		// each centered under the range of values that
+		// it spans, for example the years under the
+		// months of a nominal axis.  Groups are drawn in
+		// the Label style, and are ignored by the
+		// vertical axis.
+		Groups []TickGroup
 	}
 
+	// NiceRange specifies that, when the plot is drawn,
+	// Min and Max are extended outward to the nearest
+	// major tick positions, so that the axis begins and
+	// ends on a major tick.  Major ticks are assumed to be
+	// evenly spaced, except for axes marked by LogTicks,
+	// whose ranges are extended to whole decades.
+	NiceRange bool
+
+	// ZeroRangePadding is the distance by which Min and
+	// Max are each moved away from the data value when
+	// the plot is drawn and Min equals Max, such as when
+	// all of the data have the same value.  If it is
+	// zero, a padding of 1 is used, giving a range of
+	// [v-1, v+1] around the value v.
+	//
+	// If ZeroRangeRelative is true, ZeroRangePadding is
+	// instead a fraction of the magnitude of the value,
+	// so that 0.05 gives a range of [v-5%, v+5%].  The
+	// absolute padding of 1 is used when the value or
+	// ZeroRangePadding is zero.
+	ZeroRangePadding  float64
+	ZeroRangeRelative bool
+
 	// Scale transforms a value given in the data coordinate system
 	// to the normalized coordinate system of the axis—its distance
 	// along the axis as a fraction of the axis range.
@@ -142,9 +266,194 @@ func (a *Axis) sanitizeRange() {
 		a.Min, a.Max = a.Max, a.Min
 	}
 	if a.Min == a.Max {
-		a.Min--
-		a.Max++
+		pad := a.zeroRangePadding()
+		a.Min -= pad
+		a.Max += pad
+	}
+	if a.NiceRange {
+		a.niceRange()
+	}
+}
+
+// zeroRangePadding returns the padding on each side
+// of a single-valued axis range, as described by the
+// ZeroRangePadding and ZeroRangeRelative fields.
+func (a *Axis) zeroRangePadding() float64 {
+	pad := math.Abs(a.ZeroRangePadding)
+	if a.ZeroRangeRelative {
+		pad *= math.Abs(a.Min)
+	}
+	if pad == 0 {
+		pad = 1
+	}
+	return pad
+}
+
+// niceRange extends Min and Max outward to the nearest
+// major tick positions.
+func (a *Axis) niceRange() {
+	if _, ok := a.Tick.Marker.(LogTicks); ok {
+		if a.Min > 0 {
+			a.Min = math.Pow10(int(math.Floor(math.Log10(a.Min))))
+			a.Max = math.Pow10(int(math.Ceil(math.Log10(a.Max))))
+		}
+		return
+	}
+
+	// The bounds are rounded to multiples of the major
+	// tick spacing, itself rounded up to 1, 2 or 5 times
+	// a power of ten.  The ticker may choose different
+	// ticks for the extended range, so the range is
+	// refined until its bounds lie on major ticks.
+	const tol = 1e-9
+	for i := 0; i < 3; i++ {
+		var major []float64
+		for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+			if !t.IsMinor() {
+				major = append(major, t.Value)
+			}
+		}
+		if len(major) < 2 {
+			return
+		}
+		first, last := major[0], major[len(major)-1]
+		step := major[1] - major[0]
+		if math.Abs(first-a.Min) <= tol*step && math.Abs(last-a.Max) <= tol*step {
+			a.Min, a.Max = first, last
+			return
+		}
+		step = niceStep(step)
+		a.Min = math.Floor(a.Min/step+tol) * step
+		a.Max = math.Ceil(a.Max/step-tol) * step
+	}
+}
+
+// niceStep returns the smallest value of 1, 2 or 5 times
+// a power of ten that is not less than step.
+func niceStep(step float64) float64 {
+	mag := math.Pow10(int(math.Floor(math.Log10(step))))
+	for _, m := range []float64{1, 2, 5} {
+		if m*mag >= step*(1-1e-9) {
+			return m * mag
+		}
+	}
+	return 10 * mag
+}
+
+// relativeTicks returns whether the tick length or label
+// padding is relative to the size of the data area.
+func (a *Axis) relativeTicks() bool {
+	return a.Tick.RelativeLength != 0 || a.Tick.RelativeLabelPadding != 0
+}
+
+// resolve returns a copy of the axis with any relative
+// tick dimensions converted to lengths for a data area
+// whose smaller dimension is size, and with the tick
+// labels stacked if Tick.Stacked is set.
+func (a Axis) resolve(size vg.Length) Axis {
+	if a.Tick.RelativeLength != 0 {
+		a.Tick.Length = vg.Length(a.Tick.RelativeLength) * size
+	}
+	if a.Tick.RelativeLabelPadding != 0 {
+		a.Tick.LabelPadding = vg.Length(a.Tick.RelativeLabelPadding) * size
+	}
+	if a.Tick.Stacked && a.Tick.Marker != nil {
+		a.Tick.Label.Rotation = 0
+		a.Tick.Marker = stackedTicks{a.Tick.Marker}
+	}
+	return a
+}
+
+// wrapTicks returns a copy of the axis, which is
+// the given length, with the labels of its major
+// ticks wrapped to fit between the neighboring
+// major ticks if Tick.Wrap is set.
+func (a Axis) wrapTicks(length vg.Length) Axis {
+	if !a.Tick.Wrap || a.Tick.Stacked || a.Tick.Label.Rotation != 0 || a.Tick.Marker == nil {
+		return a
+	}
+	ticks := append([]Tick(nil), a.Tick.Marker.Ticks(a.Min, a.Max)...)
+
+	// The slots of the major ticks
+	// are found in order along the axis.
+	var major []int
+	for i, t := range ticks {
+		if n := a.Norm(t.Value); !t.IsMinor() && 0 <= n && n <= 1 {
+			major = append(major, i)
+		}
+	}
+	sort.Slice(major, func(i, j int) bool {
+		return ticks[major[i]].Value < ticks[major[j]].Value
+	})
+	for k, i := range major {
+		slot := length
+		if k > 0 {
+			d := vg.Length(a.Norm(ticks[i].Value)-a.Norm(ticks[major[k-1]].Value)) * length
+			slot = vg.Length(math.Min(float64(slot), math.Abs(float64(d))))
+		}
+		if k < len(major)-1 {
+			d := vg.Length(a.Norm(ticks[major[k+1]].Value)-a.Norm(ticks[i].Value)) * length
+			slot = vg.Length(math.Min(float64(slot), math.Abs(float64(d))))
+		}
+		ticks[i].Label = wrapLabel(a.Tick.Label, ticks[i].Label, slot)
+	}
+	a.Tick.Marker = ConstantTicks(ticks)
+	return a
+}
+
+// wrapLabel returns s broken onto multiple lines at
+// spaces so that, where possible, no line is wider
+// than width when drawn in the given style.  Existing
+// line breaks in s are kept.
+func wrapLabel(sty draw.TextStyle, s string, width vg.Length) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		var cur string
+		for _, word := range strings.Fields(line) {
+			if cur == "" {
+				cur = word
+				continue
+			}
+			if next := cur + " " + word; sty.Width(next) <= width {
+				cur = next
+				continue
+			}
+			lines = append(lines, cur)
+			cur = word
+		}
+		lines = append(lines, cur)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stackedTicks is a Ticker that returns the ticks
+// of another Ticker with the characters of their
+// labels on separate lines.
+type stackedTicks struct {
+	Ticker
+}
+
+// Ticks returns the ticks of the underlying
+// Ticker with stacked labels.
+func (t stackedTicks) Ticks(min, max float64) []Tick {
+	ticks := append([]Tick(nil), t.Ticker.Ticks(min, max)...)
+	for i, tk := range ticks {
+		ticks[i].Label = stackLabel(tk.Label)
 	}
+	return ticks
+}
+
+// stackLabel returns s with each of its
+// characters on a separate line.
+func stackLabel(s string) string {
+	var buf strings.Builder
+	for i, r := range strings.Replace(s, "\n", "", -1) {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
 }
 
 // LinearScale an be used as the value of an Axis.Scale function to
@@ -162,7 +471,7 @@ func (LinearScale) Normalize(min, max, x float64) float64 {
 // set the axis to a log scale.
 type LogScale struct{}
 
-var _ Normalizer = LogScale{}
+var _ DomainNormalizer = LogScale{}
 
 // Normalize returns the fractional logarithmic distance of
 // x between min and max.
@@ -171,19 +480,177 @@ func (LogScale) Normalize(min, max, x float64) float64 {
 	return (log(x) - logMin) / (log(max) - logMin)
 }
 
+// InDomain returns whether x is positive, implementing
+// the DomainNormalizer interface.
+func (LogScale) InDomain(x float64) bool {
+	return x > 0
+}
+
+// SymLogScale can be used as the value of an Axis.Scale function
+// to set the axis to a symmetric log scale, for data that span
+// positive and negative values over many orders of magnitude.
+// The scale is nearly linear within Threshold of zero and
+// logarithmic beyond it on both sides, with a smooth transition
+// between the two.  A Threshold that is not positive is taken
+// to be one.
+type SymLogScale struct {
+	Threshold float64
+}
+
+var _ Normalizer = SymLogScale{}
+
+// Normalize returns the fractional symmetric logarithmic
+// distance of x between min and max.
+func (s SymLogScale) Normalize(min, max, x float64) float64 {
+	t := symLogThreshold(s.Threshold)
+	symMin := symLog(min, t)
+	return (symLog(x, t) - symMin) / (symLog(max, t) - symMin)
+}
+
+// symLog returns the symmetric log transform of x
+// with the threshold t, sign(x)*log10(1+|x|/t).
+func symLog(x, t float64) float64 {
+	return math.Copysign(math.Log10(1+math.Abs(x)/t), x)
+}
+
+// symLogThreshold returns the threshold t,
+// or one if t is not positive.
+func symLogThreshold(t float64) float64 {
+	if t <= 0 {
+		return 1
+	}
+	return t
+}
+
 // Norm returns the value of x, given in the data coordinate
 // system, normalized to its distance as a fraction of the
 // range of this axis.  For example, if x is a.Min then the return
 // value is 0, and if x is a.Max then the return value is 1.
+// If the range is empty, with a.Min equal to a.Max, the
+// return value is 0.5, so that a single value is placed in
+// the center of the axis.
 func (a Axis) Norm(x float64) float64 {
+	if a.Min == a.Max {
+		return 0.5
+	}
 	return a.Scale.Normalize(a.Min, a.Max, x)
 }
 
+// extendRange changes the minimum and maximum values of
+// the axis if necessary to include min and max, ignoring
+// either that is outside of the domain of the axis' Scale.
+func (a *Axis) extendRange(min, max float64) {
+	if d, ok := a.Scale.(DomainNormalizer); ok {
+		if !d.InDomain(min) {
+			min = math.Inf(1)
+		}
+		if !d.InDomain(max) {
+			max = math.Inf(-1)
+		}
+	}
+	a.Min = math.Min(a.Min, min)
+	a.Max = math.Max(a.Max, max)
+}
+
+// TickPositions returns the normalized positions of the
+// axis' tick marks, in the order returned by Tick.Marker,
+// as given by Norm.  The axis range is first sanitized as
+// it is when the plot is drawn.  Positions outside of
+// [0, 1] are of ticks that lie outside the axis range and
+// are not drawn.
+func (a *Axis) TickPositions() []float64 {
+	a.sanitizeRange()
+	ticks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	pos := make([]float64, len(ticks))
+	for i, t := range ticks {
+		pos[i] = a.Norm(t.Value)
+	}
+	return pos
+}
+
+// MinorTickPositions returns the normalized positions of
+// the axis' minor tick marks, those without a label, in
+// the order returned by Tick.Marker.  As for TickPositions,
+// the axis range is first sanitized, and positions outside
+// of [0, 1] are of ticks that are not drawn.
+func (a *Axis) MinorTickPositions() []float64 {
+	a.sanitizeRange()
+	var pos []float64
+	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+		if t.IsMinor() {
+			pos = append(pos, a.Norm(t.Value))
+		}
+	}
+	return pos
+}
+
+// tickColor returns the color of a tick at x given
+// by Tick.ColorMap, or nil if there is no ColorMap.
+func (a Axis) tickColor(x float64) color.Color {
+	cm := a.Tick.ColorMap
+	if cm == nil {
+		return nil
+	}
+	n := math.Min(math.Max(a.Norm(x), 0), 1)
+	clr, err := cm.At(cm.Min() + n*(cm.Max()-cm.Min()))
+	if err != nil {
+		return nil
+	}
+	return clr
+}
+
+// tickLineStyle returns the style of the tick mark at x.
+func (a Axis) tickLineStyle(x float64) draw.LineStyle {
+	sty := a.Tick.LineStyle
+	if clr := a.tickColor(x); clr != nil {
+		sty.Color = clr
+	}
+	return sty
+}
+
+// tickLabelStyle returns the style of the tick label at x.
+func (a Axis) tickLabelStyle(x float64) draw.TextStyle {
+	sty := a.Tick.Label
+	if clr := a.tickColor(x); clr != nil {
+		sty.Color = clr
+	}
+	return sty
+}
+
 // drawTicks returns true if the tick marks should be drawn.
 func (a Axis) drawTicks() bool {
 	return a.Tick.Width > 0 && a.Tick.Length > 0
 }
 
+// ProbabilityScale can be used as the value of an Axis.Scale
+// function to set the axis to a probability (probit) scale, on
+// which the quantiles of the standard normal distribution are
+// linear.  Values are probabilities, and those within
+// ProbabilityEpsilon of 0 or 1, or beyond them, are clamped
+// to 0+ProbabilityEpsilon and 1-ProbabilityEpsilon.
+type ProbabilityScale struct{}
+
+var _ Normalizer = ProbabilityScale{}
+
+// ProbabilityEpsilon is the distance from 0 and 1 at which
+// ProbabilityScale clamps probabilities.
+const ProbabilityEpsilon = 1e-6
+
+// Normalize returns the fractional distance of the standard
+// normal quantile of x between those of min and max.
+func (ProbabilityScale) Normalize(min, max, x float64) float64 {
+	qMin := probit(min)
+	return (probit(x) - qMin) / (probit(max) - qMin)
+}
+
+// probit returns the standard normal quantile of the
+// probability p, clamped to within ProbabilityEpsilon
+// of 0 and 1.
+func probit(p float64) float64 {
+	p = math.Max(ProbabilityEpsilon, math.Min(1-ProbabilityEpsilon, p))
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
 // A horizontalAxis draws horizontally across the bottom
 // of a plot.
 type horizontalAxis struct {
@@ -195,6 +662,12 @@ func (a horizontalAxis) size() (h vg.Length) {
 	if a.Label.Text != "" { // We assume that the label isn't rotated.
 		h -= a.Label.Font.Extents().Descent
 		h += a.Label.Height(a.Label.Text)
+		h += a.Label.Padding
+	}
+
+	if gh := a.groupHeight(); gh > 0 {
+		h += gh
+		h += a.Tick.LabelPadding
 	}
 
 	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
@@ -203,6 +676,7 @@ func (a horizontalAxis) size() (h vg.Length) {
 			h += a.Tick.Length
 		}
 		h += tickLabelHeight(a.Tick.Label, marks)
+		h += a.Tick.LabelPadding
 	}
 	h += a.Width / 2
 	h += a.Padding
@@ -217,20 +691,34 @@ func (a horizontalAxis) draw(c draw.Canvas) {
 		y -= a.Label.Font.Extents().Descent
 		c.FillText(a.Label.TextStyle, vg.Point{X: c.Center().X, Y: y}, a.Label.Text)
 		y += a.Label.Height(a.Label.Text)
+		y += a.Label.Padding
+	}
+
+	if gh := a.groupHeight(); gh > 0 {
+		for _, g := range a.Tick.Groups {
+			x := c.X((a.Norm(g.Min) + a.Norm(g.Max)) / 2)
+			if !c.ContainsX(x) || a.Tick.HideLabels {
+				continue
+			}
+			c.FillText(a.Tick.Label, vg.Point{X: x, Y: y + gh}, g.Label)
+		}
+		y += gh
+		y += a.Tick.LabelPadding
 	}
 
 	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
 	ticklabelheight := tickLabelHeight(a.Tick.Label, marks)
 	for _, t := range marks {
 		x := c.X(a.Norm(t.Value))
-		if !c.ContainsX(x) || t.IsMinor() {
+		if !c.ContainsX(x) || t.IsMinor() || a.Tick.HideLabels {
 			continue
 		}
-		c.FillText(a.Tick.Label, vg.Point{X: x, Y: y + ticklabelheight}, t.Label)
+		c.FillText(a.tickLabelStyle(t.Value), vg.Point{X: x, Y: y + ticklabelheight}, t.Label)
 	}
 
 	if len(marks) > 0 {
 		y += ticklabelheight
+		y += a.Tick.LabelPadding
 	} else {
 		y += a.Width / 2
 	}
@@ -243,7 +731,7 @@ func (a horizontalAxis) draw(c draw.Canvas) {
 				continue
 			}
 			start := t.lengthOffset(len)
-			c.StrokeLine2(a.Tick.LineStyle, x, y+start, x, y+len)
+			c.StrokeLine2(a.tickLineStyle(t.Value), x, y+start, x, y+len)
 		}
 		y += len
 	}
@@ -251,6 +739,40 @@ func (a horizontalAxis) draw(c draw.Canvas) {
 	c.StrokeLine2(a.LineStyle, c.Min.X, y, c.Max.X, y)
 }
 
+// groupHeight returns the height of the tier
+// of group labels, or zero if the axis has no
+// group labels.
+func (a horizontalAxis) groupHeight() vg.Length {
+	var h vg.Length
+	for _, g := range a.Tick.Groups {
+		if g.Label == "" {
+			continue
+		}
+		r := a.Tick.Label.Rectangle(g.Label)
+		if r.Max.Y-r.Min.Y > h {
+			h = r.Max.Y - r.Min.Y
+		}
+	}
+	return h
+}
+
+// drawMirror draws the tick marks of the axis
+// downwards from the upper edge of a draw.Canvas.
+func (a horizontalAxis) drawMirror(c draw.Canvas) {
+	if !a.drawTicks() {
+		return
+	}
+	y := c.Max.Y
+	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+		x := c.X(a.Norm(t.Value))
+		if !c.ContainsX(x) {
+			continue
+		}
+		start := t.lengthOffset(a.Tick.Length)
+		c.StrokeLine2(a.tickLineStyle(t.Value), x, y, x, y-(a.Tick.Length-start))
+	}
+}
+
 // GlyphBoxes returns the GlyphBoxes for the tick labels.
 func (a horizontalAxis) GlyphBoxes(*Plot) []GlyphBox {
 	var boxes []GlyphBox
@@ -274,9 +796,16 @@ type verticalAxis struct {
 
 // size returns the width of the axis.
 func (a verticalAxis) size() (w vg.Length) {
-	if a.Label.Text != "" { // We assume that the label isn't rotated.
-		w -= a.Label.Font.Extents().Descent
-		w += a.Label.Height(a.Label.Text)
+	if a.Label.Text != "" {
+		if a.Label.Horizontal {
+			w += a.Label.Width(a.Label.Text) + a.Label.Width(" ")
+		} else {
+			// We assume that the label isn't rotated
+			// beyond the added quarter turn.
+			w -= a.Label.Font.Extents().Descent
+			w += a.Label.Height(a.Label.Text)
+		}
+		w += a.Label.Padding
 	}
 
 	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
@@ -285,6 +814,7 @@ func (a verticalAxis) size() (w vg.Length) {
 			w += lwidth
 			w += a.Label.Width(" ")
 		}
+		w += a.Tick.LabelPadding
 		if a.drawTicks() {
 			w += a.Tick.Length
 		}
@@ -298,13 +828,24 @@ func (a verticalAxis) size() (w vg.Length) {
 // draw draws the axis along the left side of a draw.Canvas.
 func (a verticalAxis) draw(c draw.Canvas) {
 	x := c.Min.X
-	if a.Label.Text != "" {
+	switch {
+	case a.Label.Text == "":
+	case a.Label.Horizontal:
+		sty := a.Label.TextStyle
+		sty.XAlign = draw.XLeft
+		sty.YAlign = draw.YCenter
+		c.FillText(sty, vg.Point{X: x, Y: c.Center().Y}, a.Label.Text)
+		x += a.Label.Width(a.Label.Text) + a.Label.Width(" ")
+	default:
 		sty := a.Label.TextStyle
 		sty.Rotation += math.Pi / 2
 		x += a.Label.Height(a.Label.Text)
 		c.FillText(sty, vg.Point{X: x, Y: c.Center().Y}, a.Label.Text)
 		x += -a.Label.Font.Extents().Descent
 	}
+	if a.Label.Text != "" {
+		x += a.Label.Padding
+	}
 	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
 	if w := tickLabelWidth(a.Tick.Label, marks); len(marks) > 0 && w > 0 {
 		x += w
@@ -316,12 +857,17 @@ func (a verticalAxis) draw(c draw.Canvas) {
 		if !c.ContainsY(y) || t.IsMinor() {
 			continue
 		}
-		c.FillText(a.Tick.Label, vg.Point{X: x, Y: y}, t.Label)
+		if !a.Tick.HideLabels {
+			c.FillText(a.tickLabelStyle(t.Value), vg.Point{X: x, Y: y}, t.Label)
+		}
 		major = true
 	}
 	if major {
 		x += a.Tick.Label.Width(" ")
 	}
+	if len(marks) > 0 {
+		x += a.Tick.LabelPadding
+	}
 	if a.drawTicks() && len(marks) > 0 {
 		len := a.Tick.Length
 		for _, t := range marks {
@@ -330,7 +876,7 @@ func (a verticalAxis) draw(c draw.Canvas) {
 				continue
 			}
 			start := t.lengthOffset(len)
-			c.StrokeLine2(a.Tick.LineStyle, x+start, y, x+len, y)
+			c.StrokeLine2(a.tickLineStyle(t.Value), x+start, y, x+len, y)
 		}
 		x += len
 	}
@@ -338,6 +884,23 @@ func (a verticalAxis) draw(c draw.Canvas) {
 	c.StrokeLine2(a.LineStyle, x, c.Min.Y, x, c.Max.Y)
 }
 
+// drawMirror draws the tick marks of the axis
+// leftwards from the right edge of a draw.Canvas.
+func (a verticalAxis) drawMirror(c draw.Canvas) {
+	if !a.drawTicks() {
+		return
+	}
+	x := c.Max.X
+	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+		y := c.Y(a.Norm(t.Value))
+		if !c.ContainsY(y) {
+			continue
+		}
+		start := t.lengthOffset(a.Tick.Length)
+		c.StrokeLine2(a.tickLineStyle(t.Value), x, y, x-(a.Tick.Length-start), y)
+	}
+}
+
 // GlyphBoxes returns the GlyphBoxes for the tick labels
 func (a verticalAxis) GlyphBoxes(*Plot) []GlyphBox {
 	var boxes []GlyphBox
@@ -356,19 +919,47 @@ func (a verticalAxis) GlyphBoxes(*Plot) []GlyphBox {
 
 // DefaultTicks is suitable for the Tick.Marker field of an Axis,
 // it returns a reasonable default set of tick marks.
-type DefaultTicks struct{}
+type DefaultTicks struct {
+	// MinCount and MaxCount, if non-zero, are the minimum
+	// and maximum number of labelled ticks.  A nice tick
+	// interval giving a number of labelled ticks in this
+	// range is chosen if one exists, otherwise the ticks are
+	// spaced evenly across the axis range.  Counts less
+	// than two are treated as two.
+	MinCount, MaxCount int
+
+	// Format, if not empty, is the fmt package
+	// format used for the tick labels, for example
+	// "%.3f".  Otherwise the label precision is
+	// chosen from the interval between the labels.
+	Format string
+
+	// SIPrefix specifies that the label values are
+	// divided by a power of 1000 chosen from the largest
+	// label magnitude, and followed by the SI prefix
+	// for that power, for example "2.5M" for 2500000.
+	// The same prefix is used for all of the labels.
+	// If Format is also given, it is used to format
+	// the divided values.
+	SIPrefix bool
+
+	// Unit, if not empty, is the unit of the values,
+	// which follows the SI prefix of labels when
+	// SIPrefix is set, for example "2.5 MHz".
+	Unit string
+}
 
 var _ Ticker = DefaultTicks{}
 
 // Ticks returns Ticks in the specified range.
-func (DefaultTicks) Ticks(min, max float64) []Tick {
+func (t DefaultTicks) Ticks(min, max float64) []Tick {
 	if max <= min {
 		panic("illegal range")
 	}
 
 	const suggestedTicks = 3
 
-	labels, step, q, mag := talbotLinHanrahan(min, max, suggestedTicks, withinData, nil, nil, nil)
+	labels, step, q, mag := t.labels(min, max, suggestedTicks)
 	majorDelta := step * math.Pow10(mag)
 	if q == 0 {
 		// Simple fall back was chosen, so
@@ -376,21 +967,48 @@ func (DefaultTicks) Ticks(min, max float64) []Tick {
 		majorDelta = labels[1] - labels[0]
 	}
 
-	// Choose a reasonable, but ad
-	// hoc formatting for labels.
+	// Choose the label precision from the interval
+	// and the labels, which need not lie on multiples
+	// of the interval, so that the labels are neither
+	// truncated nor padded with zeros.  Very small and
+	// very large values use exponent format.
+	const maxPrec = 6
 	fc := byte('f')
-	var off int
-	if mag < -1 || 6 < mag {
-		off = 1
+	var prec int
+	if mag < -4 || 6 < mag {
 		fc = 'g'
+		off := 1
+		if math.Trunc(q) != q {
+			off += 2
+		}
+		prec = minInt(maxPrec, maxInt(off, -mag))
+	} else {
+		prec = decimals(majorDelta, maxPrec)
+		for _, v := range labels {
+			prec = maxInt(prec, decimals(v, maxPrec))
+		}
 	}
-	if math.Trunc(q) != q {
-		off += 2
+	scale, suffix := 1.0, ""
+	if t.SIPrefix {
+		var exp int
+		exp, suffix = siPrefix(labels)
+		scale = math.Pow10(exp)
+		if t.Unit != "" {
+			suffix = " " + suffix + t.Unit
+		}
+		fc = 'f'
+		prec = decimals(majorDelta/scale, maxPrec)
+		for _, v := range labels {
+			prec = maxInt(prec, decimals(v/scale, maxPrec))
+		}
 	}
-	prec := minInt(6, maxInt(off, -mag))
 	var ticks []Tick
 	for _, v := range labels {
-		ticks = append(ticks, Tick{Value: v, Label: strconv.FormatFloat(v, fc, prec, 64)})
+		label := strconv.FormatFloat(v/scale, fc, prec, 64)
+		if t.Format != "" {
+			label = fmt.Sprintf(t.Format, v/scale)
+		}
+		ticks = append(ticks, Tick{Value: v, Label: label + suffix})
 	}
 
 	var minorDelta float64
@@ -436,6 +1054,91 @@ func (DefaultTicks) Ticks(min, max float64) []Tick {
 	return ticks
 }
 
+// labels returns the labelled tick values for the range, and
+// the step, q and magnitude with which they were chosen, as
+// returned by talbotLinHanrahan, with the number of labels
+// constrained by MinCount and MaxCount.
+func (t DefaultTicks) labels(min, max float64, want int) (labels []float64, step, q float64, mag int) {
+	if t.MinCount == 0 && t.MaxCount == 0 {
+		return talbotLinHanrahan(min, max, want, withinData, nil, nil, nil)
+	}
+
+	lo := maxInt(2, t.MinCount)
+	hi := t.MaxCount
+	if hi == 0 {
+		// Without an upper bound, search a
+		// limited number of larger counts.
+		hi = maxInt(lo, want) + 10
+	}
+	hi = maxInt(lo, hi)
+
+	// Try the wanted counts closest to the
+	// suggested count first.
+	want = maxInt(lo, minInt(hi, want))
+	for d := 0; want-d >= lo || want+d <= hi; d++ {
+		for _, n := range []int{want - d, want + d} {
+			if n < lo || hi < n || (d == 0 && n != want-d) {
+				continue
+			}
+			labels, step, q, mag = talbotLinHanrahan(min, max, n, withinData, nil, nil, nil)
+			if lo <= len(labels) && len(labels) <= hi {
+				return labels, step, q, mag
+			}
+		}
+	}
+
+	// No nice interval gives an acceptable number
+	// of labels, so space them evenly.
+	labels = make([]float64, want)
+	step = (max - min) / float64(want-1)
+	for i := range labels {
+		labels[i] = min + float64(i)*step
+	}
+	labels[want-1] = max
+	return labels, step, 0, int(math.Floor(math.Log10(step)))
+}
+
+// siPrefixes are the SI prefixes for the powers of
+// 1000 from 10^-24 to 10^24.
+var siPrefixes = []string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+// siPrefix returns the exponent of the power of 1000 by
+// which the values are divided to label them with an SI
+// prefix, and the prefix.  The power is the largest that
+// does not exceed the largest magnitude of the values.
+func siPrefix(vs []float64) (exp int, prefix string) {
+	var max float64
+	for _, v := range vs {
+		max = math.Max(max, math.Abs(v))
+	}
+	const (
+		minExp = -24
+		maxExp = 24
+	)
+	if max != 0 {
+		for exp < maxExp && max >= math.Pow10(exp+3) {
+			exp += 3
+		}
+		for exp > minExp && max < math.Pow10(exp) {
+			exp -= 3
+		}
+	}
+	return exp, siPrefixes[(exp-minExp)/3]
+}
+
+// decimals returns the number of decimal places, up
+// to max, needed to show v exactly.
+func decimals(v float64, max int) int {
+	const tol = 1e-9
+	for n := 0; n < max; n++ {
+		s := math.Abs(v) * math.Pow10(n)
+		if math.Abs(s-math.Floor(s+0.5)) <= tol*math.Max(1, s) {
+			return n
+		}
+	}
+	return max
+}
+
 func minInt(a, b int) int {
 	if a < b {
 		return a
@@ -450,6 +1153,13 @@ func maxInt(a, b int) int {
 	return b
 }
 
+func maxLength(a, b vg.Length) vg.Length {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // LogTicks is suitable for the Tick.Marker field of an Axis,
 // it returns tick marks suitable for a log-scale axis.
 type LogTicks struct{}
@@ -479,8 +1189,106 @@ func (LogTicks) Ticks(min, max float64) []Tick {
 	return ticks
 }
 
+// SymLogTicks is suitable for the Tick.Marker field of an Axis
+// using a SymLogScale with the same Threshold.  Major ticks are
+// placed at zero and at the threshold multiplied by powers of
+// ten on both sides of zero, and minor ticks at the multiples
+// in between.
+type SymLogTicks struct {
+	Threshold float64
+}
+
+var _ Ticker = SymLogTicks{}
+
+// Ticks returns Ticks in a specified range.  No
+// ticks are returned if min or max is not finite.
+func (t SymLogTicks) Ticks(min, max float64) []Tick {
+	if math.IsInf(min, 0) || math.IsInf(max, 0) || math.IsNaN(min) || math.IsNaN(max) {
+		return nil
+	}
+	thr := symLogThreshold(t.Threshold)
+	lim := math.Max(math.Abs(min), math.Abs(max))
+
+	// mags are the magnitudes of the ticks
+	// on each side of zero, in increasing order.
+	var mags []Tick
+	for v := thr; v <= lim*(1+1e-9) && !math.IsInf(v, 1); v *= 10 {
+		mags = append(mags, Tick{Value: v, Label: formatFloatTick(v, -1)})
+		for i := 2; i < 10 && v*float64(i) <= lim; i++ {
+			mags = append(mags, Tick{Value: v * float64(i)})
+		}
+	}
+
+	var ticks []Tick
+	add := func(tk Tick) {
+		if min <= tk.Value && tk.Value <= max {
+			ticks = append(ticks, tk)
+		}
+	}
+	for i := len(mags) - 1; i >= 0; i-- {
+		tk := mags[i]
+		tk.Value = -tk.Value
+		if tk.Label != "" {
+			tk.Label = "-" + tk.Label
+		}
+		add(tk)
+	}
+	add(Tick{Value: 0, Label: "0"})
+	for _, tk := range mags {
+		add(tk)
+	}
+	return ticks
+}
+
+// ProbabilityTicks is suitable for the Tick.Marker field of an Axis,
+// it returns tick marks suitable for a probability-scale axis.
+// Major ticks are placed at 0.001, 0.01, 0.1, 0.5, 0.9, 0.99 and
+// 0.999, and minor ticks at other common probabilities.
+type ProbabilityTicks struct{}
+
+var _ Ticker = ProbabilityTicks{}
+
+// probabilityTicks are the tick values of ProbabilityTicks,
+// with a label for each major tick.
+var probabilityTicks = []Tick{
+	{Value: 0.001, Label: "0.001"},
+	{Value: 0.005},
+	{Value: 0.01, Label: "0.01"},
+	{Value: 0.02},
+	{Value: 0.05},
+	{Value: 0.1, Label: "0.1"},
+	{Value: 0.2},
+	{Value: 0.3},
+	{Value: 0.4},
+	{Value: 0.5, Label: "0.5"},
+	{Value: 0.6},
+	{Value: 0.7},
+	{Value: 0.8},
+	{Value: 0.9, Label: "0.9"},
+	{Value: 0.95},
+	{Value: 0.98},
+	{Value: 0.99, Label: "0.99"},
+	{Value: 0.995},
+	{Value: 0.999, Label: "0.999"},
+}
+
+// Ticks returns Ticks in a specified range
+func (ProbabilityTicks) Ticks(min, max float64) []Tick {
+	var ticks []Tick
+	for _, t := range probabilityTicks {
+		if t.Value >= min && t.Value <= max {
+			ticks = append(ticks, t)
+		}
+	}
+	return ticks
+}
+
 // ConstantTicks is suitable for the Tick.Marker field of an Axis.
-// This function returns the given set of ticks.
+// This function returns the given set of ticks.  Each tick is
+// placed at its Value, mapped through the axis' Scale in the same
+// way as the plotted data, and is labelled with its Label as given,
+// so the labels may be arbitrary text that is not derived from the
+// tick values.
 type ConstantTicks []Tick
 
 var _ Ticker = ConstantTicks{}
@@ -490,6 +1298,44 @@ func (ts ConstantTicks) Ticks(float64, float64) []Tick {
 	return ts
 }
 
+// StepTicks is suitable for the Tick.Marker field of an Axis.
+// It returns labelled tick marks at Start+i*Step for each
+// integer i such that the tick lies within the axis range.
+type StepTicks struct {
+	// Start is the value of one of the ticks, which
+	// need not lie within the axis range.
+	Start float64
+
+	// Step is the distance between neighboring
+	// ticks.  No ticks are returned if Step is
+	// not positive.
+	Step float64
+}
+
+var _ Ticker = StepTicks{}
+
+// Ticks returns Ticks in a specified range
+func (t StepTicks) Ticks(min, max float64) []Tick {
+	if !(t.Step > 0) {
+		return nil
+	}
+	// Allow for rounding so that ticks at the
+	// ends of the range are not lost.
+	const tol = 1e-9
+	first := math.Ceil((min-t.Start)/t.Step - tol)
+	last := math.Floor((max-t.Start)/t.Step + tol)
+	prec := maxInt(decimals(t.Start, 6), decimals(t.Step, 6))
+	var ticks []Tick
+	for i := first; i <= last; i++ {
+		// Ticks are computed from Start rather than
+		// accumulated so that rounding errors do not
+		// build up.
+		v := t.Start + i*t.Step
+		ticks = append(ticks, Tick{Value: v, Label: strconv.FormatFloat(v, 'f', prec, 64)})
+	}
+	return ticks
+}
+
 // UnixTimeIn returns a time conversion function for the given location.
 func UnixTimeIn(loc *time.Location) func(t float64) time.Time {
 	return func(t float64) time.Time {
@@ -551,6 +1397,17 @@ type Tick struct {
 	Label string
 }
 
+// A TickGroup is a label for a range of values
+// of an axis, drawn below the tick labels.
+type TickGroup struct {
+	// Min and Max are the data values at the
+	// ends of the range of the group.
+	Min, Max float64
+
+	// Label is the text of the group label.
+	Label string
+}
+
 // IsMinor returns true if this is a minor tick mark.
 func (t Tick) IsMinor() bool {
 	return t.Label == ""
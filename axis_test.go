@@ -5,9 +5,17 @@
 package plot
 
 import (
+	"image/color"
 	"math"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
+
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
 )
 
 var axisSmallTickTests = []struct {
@@ -105,3 +113,754 @@ func labelsOf(ticks []Tick) []string {
 	}
 	return labels
 }
+
+func TestAxisResolve(t *testing.T) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	length := a.Tick.Length
+
+	got := a.resolve(200)
+	if got.Tick.Length != length || got.Tick.LabelPadding != 0 {
+		t.Errorf("unexpected absolute tick dimensions: got length=%v padding=%v, want length=%v padding=0",
+			got.Tick.Length, got.Tick.LabelPadding, length)
+	}
+
+	a.Tick.RelativeLength = 0.05
+	a.Tick.RelativeLabelPadding = 0.01
+	got = a.resolve(200)
+	if got.Tick.Length != 10 || got.Tick.LabelPadding != 2 {
+		t.Errorf("unexpected relative tick dimensions: got length=%v padding=%v, want length=10 padding=2",
+			got.Tick.Length, got.Tick.LabelPadding)
+	}
+	if a.Tick.Length != length {
+		t.Errorf("resolve modified the receiver: got length=%v want %v", a.Tick.Length, length)
+	}
+}
+
+func TestRelativeTicksDataArea(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "title"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+	p.X.Tick.RelativeLength = 0.05
+	p.Y.Tick.RelativeLabelPadding = 0.02
+	p.X.sanitizeRange()
+	p.Y.sanitizeRange()
+
+	c := draw.NewCanvas(new(recorder.Canvas), 300, 200)
+	x, y := p.resolveAxes(c)
+	size := minDimension(p.dataArea(c))
+	if math.Abs(float64(x.Tick.Length-0.05*size)) > 1e-6 {
+		t.Errorf("unexpected relative tick length: got:%v want:%v", x.Tick.Length, 0.05*size)
+	}
+	if math.Abs(float64(y.Tick.LabelPadding-0.02*size)) > 1e-6 {
+		t.Errorf("unexpected relative label padding: got:%v want:%v", y.Tick.LabelPadding, 0.02*size)
+	}
+}
+
+func TestAxisColors(t *testing.T) {
+	var (
+		labelColor = color.NRGBA{R: 255, A: 255}
+		tickColor  = color.NRGBA{G: 255, A: 255}
+		lineColor  = color.NRGBA{B: 255, A: 255}
+	)
+	for _, vert := range []bool{horizontal, vertical} {
+		a, err := makeAxis(vert)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		a.Min, a.Max = 0, 10
+		a.Tick.Label.Color = labelColor
+		a.Tick.Color = tickColor
+		a.Color = lineColor
+
+		var r recorder.Canvas
+		c := draw.NewCanvas(&r, 100, 100)
+		if vert {
+			verticalAxis{a}.draw(c)
+		} else {
+			horizontalAxis{a}.draw(c)
+		}
+
+		var (
+			cur     color.Color
+			strokes []color.Color
+		)
+		for _, act := range r.Actions {
+			switch act := act.(type) {
+			case *recorder.SetColor:
+				cur = act.Color
+			case *recorder.FillString:
+				if cur != labelColor {
+					t.Errorf("unexpected tick label color for vertical=%t: got:%v want:%v", vert, cur, labelColor)
+				}
+			case *recorder.Stroke:
+				strokes = append(strokes, cur)
+			}
+		}
+		if len(strokes) < 2 {
+			t.Fatalf("unexpected number of strokes for vertical=%t: %d", vert, len(strokes))
+		}
+		for _, got := range strokes[:len(strokes)-1] {
+			if got != tickColor {
+				t.Errorf("unexpected tick color for vertical=%t: got:%v want:%v", vert, got, tickColor)
+			}
+		}
+		if got := strokes[len(strokes)-1]; got != lineColor {
+			t.Errorf("unexpected axis line color for vertical=%t: got:%v want:%v", vert, got, lineColor)
+		}
+	}
+}
+
+func TestProbabilityScale(t *testing.T) {
+	var s ProbabilityScale
+	for _, test := range []struct {
+		min, max, x float64
+		want        float64
+	}{
+		{min: 0.1, max: 0.9, x: 0.5, want: 0.5},
+		{min: 0.1, max: 0.9, x: 0.1, want: 0},
+		{min: 0.1, max: 0.9, x: 0.9, want: 1},
+		{min: 0, max: 1, x: 0.5, want: 0.5},
+		{min: 0, max: 1, x: 0, want: 0},
+		{min: 0, max: 1, x: 1, want: 1},
+		{min: 0, max: 1, x: -1, want: 0},
+		{min: 0, max: 1, x: 2, want: 1},
+	} {
+		got := s.Normalize(test.min, test.max, test.x)
+		if math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected normalized value for x=%v in [%v, %v]: got:%v want:%v",
+				test.x, test.min, test.max, got, test.want)
+		}
+	}
+	// Quantiles are linear, so equal distances in
+	// standard deviations are equal distances on the axis.
+	lo := s.Normalize(0.01, 0.99, 0.158655253931457)
+	hi := s.Normalize(0.01, 0.99, 0.841344746068543)
+	if math.Abs((0.5-lo)-(hi-0.5)) > 1e-9 {
+		t.Errorf("normalized quantiles not symmetric: got %v and %v", lo, hi)
+	}
+
+	var labels []string
+	for _, tk := range (ProbabilityTicks{}).Ticks(0.05, 0.95) {
+		if !tk.IsMinor() {
+			labels = append(labels, tk.Label)
+		}
+	}
+	if want := []string{"0.1", "0.5", "0.9"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("unexpected major tick labels: got:%v want:%v", labels, want)
+	}
+}
+
+func TestSymLogScale(t *testing.T) {
+	s := SymLogScale{Threshold: 1}
+	if got := s.Normalize(-100, 100, 0); math.Abs(got-0.5) > 1e-12 {
+		t.Errorf("unexpected normalized zero: got:%v want:0.5", got)
+	}
+	if lo, hi := s.Normalize(-100, 100, -10), s.Normalize(-100, 100, 10); math.Abs(lo+hi-1) > 1e-12 {
+		t.Errorf("normalized values not symmetric: got %v and %v", lo, hi)
+	}
+	// Near zero the scale is nearly linear, and far from
+	// zero each decade has nearly the same length.
+	small := s.Normalize(-100, 100, 0.002) - s.Normalize(-100, 100, 0.001)
+	if ratio := small / (s.Normalize(-100, 100, 0.001) - s.Normalize(-100, 100, 0)); math.Abs(ratio-1) > 1e-2 {
+		t.Errorf("scale not linear near zero: got ratio %v", ratio)
+	}
+	d1 := s.Normalize(-1e6, 1e6, 1e5) - s.Normalize(-1e6, 1e6, 1e4)
+	d2 := s.Normalize(-1e6, 1e6, 1e6) - s.Normalize(-1e6, 1e6, 1e5)
+	if math.Abs(d1-d2) > 1e-4 {
+		t.Errorf("decades not of equal length: got %v and %v", d1, d2)
+	}
+
+	var labels []string
+	var values []float64
+	for _, tk := range (SymLogTicks{Threshold: 1}).Ticks(-50, 200) {
+		values = append(values, tk.Value)
+		if !tk.IsMinor() {
+			labels = append(labels, tk.Label)
+		}
+	}
+	if want := []string{"-10", "-1", "0", "1", "10", "100"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("unexpected major tick labels: got:%v want:%v", labels, want)
+	}
+	if !sort.Float64sAreSorted(values) {
+		t.Errorf("tick values not sorted: %v", values)
+	}
+	if values[0] != -50 || values[len(values)-1] != 200 {
+		t.Errorf("unexpected tick value range: got [%v, %v] want [-50, 200]", values[0], values[len(values)-1])
+	}
+
+	for _, r := range [][2]float64{
+		{math.Inf(-1), 10},
+		{-10, math.Inf(1)},
+		{math.Inf(-1), math.Inf(1)},
+		{math.NaN(), 10},
+	} {
+		if ticks := (SymLogTicks{Threshold: 1}).Ticks(r[0], r[1]); ticks != nil {
+			t.Errorf("unexpected ticks for range [%v, %v]: got:%v want:nil", r[0], r[1], ticks)
+		}
+	}
+	if ticks := (SymLogTicks{Threshold: 1}).Ticks(-math.MaxFloat64, math.MaxFloat64); len(ticks) == 0 {
+		t.Error("no ticks for largest finite range")
+	}
+}
+
+func TestNiceRange(t *testing.T) {
+	for _, test := range []struct {
+		min, max float64
+		marker   Ticker
+		wantMin  float64
+		wantMax  float64
+	}{
+		{min: 0.37, max: 9.82, marker: DefaultTicks{}, wantMin: 0, wantMax: 10},
+		{min: 0, max: 10, marker: DefaultTicks{}, wantMin: 0, wantMax: 10},
+		{min: -13, max: 47, marker: DefaultTicks{}, wantMin: -20, wantMax: 60},
+		{min: 3, max: 420, marker: LogTicks{}, wantMin: 1, wantMax: 1000},
+	} {
+		a, err := makeAxis(horizontal)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		a.Min, a.Max = test.min, test.max
+		a.Tick.Marker = test.marker
+		a.NiceRange = true
+		a.sanitizeRange()
+		if a.Min != test.wantMin || a.Max != test.wantMax {
+			t.Errorf("unexpected nice range for [%v, %v]: got:[%v, %v] want:[%v, %v]",
+				test.min, test.max, a.Min, a.Max, test.wantMin, test.wantMax)
+		}
+	}
+}
+
+func TestDefaultTicksCount(t *testing.T) {
+	for _, test := range []struct {
+		min, max float64
+		lo, hi   int
+	}{
+		{min: 0, max: 10, lo: 3, hi: 8},
+		{min: 0, max: 10, lo: 6, hi: 8},
+		{min: 0.37, max: 9.82, lo: 7, hi: 7},
+		{min: -1, max: 1, lo: 5, hi: 5},
+		{min: 0, max: 1, lo: 10, hi: 0},
+		{min: 0, max: 1, lo: 0, hi: 2},
+	} {
+		ticks := DefaultTicks{MinCount: test.lo, MaxCount: test.hi}.Ticks(test.min, test.max)
+		var major []float64
+		for _, tk := range ticks {
+			if !tk.IsMinor() {
+				major = append(major, tk.Value)
+			}
+		}
+		n := len(major)
+		lo := test.lo
+		if lo < 2 {
+			lo = 2
+		}
+		if n < lo || (test.hi != 0 && n > test.hi) {
+			t.Errorf("unexpected number of labelled ticks for [%v, %v] with counts [%d, %d]: got:%d",
+				test.min, test.max, test.lo, test.hi, n)
+		}
+		for i := 1; i < n; i++ {
+			if major[i] <= major[i-1] {
+				t.Errorf("labelled ticks not increasing for [%v, %v]: %v", test.min, test.max, major)
+				break
+			}
+		}
+	}
+}
+
+func TestDefaultTicksLabelPrecision(t *testing.T) {
+	for _, test := range []struct {
+		min, max float64
+		format   string
+		want     []string
+	}{
+		{min: 0.01, max: 0.19, want: []string{"0.025", "0.100", "0.175"}},
+		{min: 0.04, max: 0.16, want: []string{"0.05", "0.10", "0.15"}},
+		{min: 1e-7, max: 9e-7, want: []string{"1e-07", "3e-07", "5e-07", "7e-07", "9e-07"}},
+		{min: 0, max: 75, want: []string{"0", "25", "50", "75"}},
+		{min: 0, max: 1, want: []string{"0.0", "0.5", "1.0"}},
+		{min: 0, max: 75, format: "%.1f", want: []string{"0.0", "25.0", "50.0", "75.0"}},
+	} {
+		var got []string
+		for _, tk := range (DefaultTicks{Format: test.format}).Ticks(test.min, test.max) {
+			if !tk.IsMinor() {
+				got = append(got, tk.Label)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected labels for [%v, %v] with format %q: got:%q want:%q",
+				test.min, test.max, test.format, got, test.want)
+		}
+	}
+}
+
+func TestTickPositions(t *testing.T) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 10, 1
+	a.Scale = LogScale{}
+	a.Tick.Marker = ConstantTicks{{Value: 1}, {Value: 10}, {Value: 100}}
+	got := a.TickPositions()
+	want := []float64{0, 1, 2}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of positions: got:%d want:%d", len(got), len(want))
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Errorf("unexpected position %d: got:%v want:%v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMinorTickPositions(t *testing.T) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 0, 4
+	a.Tick.Marker = ConstantTicks{
+		{Value: 0, Label: "0"}, {Value: 1}, {Value: 2, Label: "2"}, {Value: 3}, {Value: 4, Label: "4"},
+	}
+	got := a.MinorTickPositions()
+	want := []float64{0.25, 0.75}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected minor tick positions: got:%v want:%v", got, want)
+	}
+}
+
+func TestStackedTickLabels(t *testing.T) {
+	marks := ConstantTicks{{Value: 1, Label: "abc"}, {Value: 2, Label: "de"}}
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 0, 3
+	a.Tick.Marker = marks
+	a.Tick.Label.Rotation = math.Pi / 4
+	plain := horizontalAxis{a.resolve(100)}.size()
+
+	a.Tick.Stacked = true
+	x := horizontalAxis{a.resolve(100)}
+	if x.Tick.Label.Rotation != 0 {
+		t.Errorf("unexpected rotation of stacked labels: got:%v want:0", x.Tick.Label.Rotation)
+	}
+	a.Tick.Label.Rotation = 0
+	flat := horizontalAxis{a}.size()
+	e := a.Tick.Label.Font.Extents()
+	if got, want := x.size()-flat, 2*e.Height; math.Abs(float64(got-want)) > 1e-9 {
+		t.Errorf("unexpected extra height for stacked labels: got:%v want:%v", got, want)
+	}
+	if x.size() <= plain {
+		t.Errorf("stacked axis not taller than rotated axis: got:%v rotated:%v", x.size(), plain)
+	}
+
+	var r recorder.Canvas
+	x.draw(draw.NewCanvas(&r, 100, 100))
+	var got []string
+	for _, act := range r.Actions {
+		if fs, ok := act.(*recorder.FillString); ok {
+			got = append(got, fs.String)
+		}
+	}
+	want := []string{"a", "b", "c", "d", "e"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected label strings: got:%q want:%q", got, want)
+	}
+	if marks[0].Label != "abc" {
+		t.Errorf("marker labels modified: got:%q", marks[0].Label)
+	}
+}
+
+func TestStepTicks(t *testing.T) {
+	for _, test := range []struct {
+		ticks    StepTicks
+		min, max float64
+		want     []Tick
+	}{
+		{
+			ticks: StepTicks{Start: 0, Step: 5},
+			min:   -3, max: 12,
+			want: []Tick{{Value: 0, Label: "0"}, {Value: 5, Label: "5"}, {Value: 10, Label: "10"}},
+		},
+		{
+			ticks: StepTicks{Start: -2.5, Step: 2.5},
+			min:   -5, max: 0,
+			want: []Tick{{Value: -5, Label: "-5.0"}, {Value: -2.5, Label: "-2.5"}, {Value: 0, Label: "0.0"}},
+		},
+		{
+			ticks: StepTicks{Start: 0, Step: 0.1},
+			min:   0.7, max: 1,
+			want: []Tick{{Value: 0.7000000000000001, Label: "0.7"}, {Value: 0.8, Label: "0.8"}, {Value: 0.9, Label: "0.9"}, {Value: 1, Label: "1.0"}},
+		},
+		{
+			ticks: StepTicks{Start: 0, Step: 0},
+			min:   0, max: 1,
+			want: nil,
+		},
+	} {
+		got := test.ticks.Ticks(test.min, test.max)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected ticks for %+v over [%v, %v]:\ngot: %v\nwant:%v", test.ticks, test.min, test.max, got, test.want)
+		}
+	}
+}
+
+func TestConstantTicksSemanticLabels(t *testing.T) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 1, 1000
+	a.Scale = LogScale{}
+	a.Tick.Marker = ConstantTicks{
+		{Value: 1, Label: "Low"},
+		{Value: 10, Label: "Med"},
+		{Value: 100, Label: "High"},
+		{Value: 316.2},
+	}
+
+	c := draw.NewCanvas(new(recorder.Canvas), 300, 100)
+	r := c.Canvas.(*recorder.Canvas)
+	x := horizontalAxis{a.resolve(300)}
+	x.draw(c)
+
+	// The labels are centered on the positions of the
+	// tick values, which are those of data drawn at the
+	// same values.
+	want := map[string]float64{"Low": 1, "Med": 10, "High": 100}
+	got := make(map[string]bool)
+	for _, act := range r.Actions {
+		fs, ok := act.(*recorder.FillString)
+		if !ok {
+			continue
+		}
+		v, ok := want[fs.String]
+		if !ok {
+			t.Errorf("unexpected label %q", fs.String)
+			continue
+		}
+		got[fs.String] = true
+		center := fs.Point.X + x.Tick.Label.Width(fs.String)/2
+		if pos := c.X(a.Norm(v)); math.Abs(float64(center-pos)) > 1e-9 {
+			t.Errorf("unexpected position of label %q: got:%v want:%v", fs.String, center, pos)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected labels drawn: got:%v want:%v", got, want)
+	}
+}
+
+func TestDefaultTicksSIPrefix(t *testing.T) {
+	for _, test := range []struct {
+		ticks    DefaultTicks
+		min, max float64
+		want     []string
+	}{
+		{
+			ticks: DefaultTicks{SIPrefix: true},
+			min:   0, max: 3000,
+			want: []string{"0k", "1k", "2k", "3k"},
+		},
+		{
+			ticks: DefaultTicks{SIPrefix: true},
+			min:   0, max: 5e6,
+			want: []string{"0.0M", "2.5M", "5.0M"},
+		},
+		{
+			ticks: DefaultTicks{SIPrefix: true, Unit: "Hz"},
+			min:   0, max: 5e9,
+			want: []string{"0.0 GHz", "2.5 GHz", "5.0 GHz"},
+		},
+		{
+			ticks: DefaultTicks{SIPrefix: true, Unit: "s"},
+			min:   0, max: 0.003,
+			want: []string{"0 ms", "1 ms", "2 ms", "3 ms"},
+		},
+		{
+			ticks: DefaultTicks{SIPrefix: true},
+			min:   0, max: 30,
+			want: []string{"0", "10", "20", "30"},
+		},
+		{
+			ticks: DefaultTicks{SIPrefix: true, Format: "%.2f"},
+			min:   0, max: 3000,
+			want: []string{"0.00k", "1.00k", "2.00k", "3.00k"},
+		},
+	} {
+		var got []string
+		for _, tick := range test.ticks.Ticks(test.min, test.max) {
+			if tick.Label != "" {
+				got = append(got, tick.Label)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected labels for %+v over [%v, %v]: got:%q want:%q", test.ticks, test.min, test.max, got, test.want)
+		}
+	}
+}
+
+func TestVerticalAxisHorizontalLabel(t *testing.T) {
+	a, err := makeAxis(vertical)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 0, 10
+	a.Label.Text = "kg"
+
+	rotated := verticalAxis{a}.size()
+	a.Label.Horizontal = true
+	y := verticalAxis{a}
+	want := rotated - (a.Label.Height(a.Label.Text) - a.Label.Font.Extents().Descent) +
+		a.Label.Width(a.Label.Text) + a.Label.Width(" ")
+	if got := y.size(); math.Abs(float64(got-want)) > 1e-9 {
+		t.Errorf("unexpected width with horizontal label: got:%v want:%v", got, want)
+	}
+
+	var r recorder.Canvas
+	y.draw(draw.NewCanvas(&r, 100, 100))
+	for _, act := range r.Actions {
+		switch act := act.(type) {
+		case *recorder.Rotate:
+			t.Errorf("unexpected rotation of horizontal label: %v", act.Angle)
+		case *recorder.FillString:
+			if act.String != a.Label.Text {
+				continue
+			}
+			if act.Point.X != 0 {
+				t.Errorf("unexpected label position: got x=%v want x=0", act.Point.X)
+			}
+			return
+		}
+	}
+	t.Errorf("label not drawn")
+}
+
+func TestAxisLabelPadding(t *testing.T) {
+	const pad = 7
+
+	x, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	x.Min, x.Max = 0, 10
+	x.Label.Text = "x"
+	y, err := makeAxis(vertical)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	y.Min, y.Max = 0, 10
+	y.Label.Text = "y"
+
+	// labelPos returns the position of the
+	// axis label drawn by draw.
+	labelPos := func(drawAxis func(draw.Canvas), label string) vg.Point {
+		var r recorder.Canvas
+		drawAxis(draw.NewCanvas(&r, 100, 100))
+		for _, act := range r.Actions {
+			if fs, ok := act.(*recorder.FillString); ok && fs.String == label {
+				return fs.Point
+			}
+		}
+		t.Fatalf("label %q not drawn", label)
+		return vg.Point{}
+	}
+
+	h0 := horizontalAxis{x}.size()
+	w0 := verticalAxis{y}.size()
+	x.Label.Padding = pad
+	y.Label.Padding = pad
+	if got := (horizontalAxis{x}).size(); got != h0+pad {
+		t.Errorf("unexpected horizontal axis height: got:%v want:%v", got, h0+pad)
+	}
+	if got := (verticalAxis{y}).size(); got != w0+pad {
+		t.Errorf("unexpected vertical axis width: got:%v want:%v", got, w0+pad)
+	}
+
+	// The tick labels move away from the axis labels,
+	// which stay at the edge of the canvas.
+	x0 := labelPos(horizontalAxis{Axis: x}.draw, "x")
+	x.Label.Padding = 0
+	x1 := labelPos(horizontalAxis{Axis: x}.draw, "x")
+	if x0 != x1 {
+		t.Errorf("horizontal axis label moved: got:%v want:%v", x0, x1)
+	}
+	t0 := labelPos(horizontalAxis{Axis: x}.draw, "10")
+	x.Label.Padding = pad
+	t1 := labelPos(horizontalAxis{Axis: x}.draw, "10")
+	if t1.Y-t0.Y != pad {
+		t.Errorf("unexpected shift of horizontal tick labels: got:%v want:%v", t1.Y-t0.Y, pad)
+	}
+
+	t1 = labelPos(verticalAxis{Axis: y}.draw, "10")
+	y.Label.Padding = 0
+	t0 = labelPos(verticalAxis{Axis: y}.draw, "10")
+	if t1.X-t0.X != pad {
+		t.Errorf("unexpected shift of vertical tick labels: got:%v want:%v", t1.X-t0.X, pad)
+	}
+}
+
+func TestTickColorMap(t *testing.T) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 0, 10
+
+	// labelColors returns the colors that the
+	// tick labels are drawn in.
+	labelColors := func() map[string]color.Color {
+		var r recorder.Canvas
+		horizontalAxis{a}.draw(draw.NewCanvas(&r, 100, 100))
+		var clr color.Color
+		colors := make(map[string]color.Color)
+		for _, act := range r.Actions {
+			switch act := act.(type) {
+			case *recorder.SetColor:
+				clr = act.Color
+			case *recorder.FillString:
+				colors[act.String] = clr
+			}
+		}
+		return colors
+	}
+
+	for label, clr := range labelColors() {
+		if clr != a.Tick.Label.Color {
+			t.Errorf("unexpected color of label %q without color map: got:%v want:%v", label, clr, a.Tick.Label.Color)
+		}
+	}
+
+	cm := moreland.Kindlmann()
+	cm.SetMin(-1)
+	cm.SetMax(1)
+	a.Tick.ColorMap = cm
+	colors := labelColors()
+	for _, test := range []struct {
+		label string
+		v     float64
+	}{
+		{label: "0", v: -1},
+		{label: "10", v: 1},
+	} {
+		want, err := cm.At(test.v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if colors[test.label] != want {
+			t.Errorf("unexpected color of label %q: got:%v want:%v", test.label, colors[test.label], want)
+		}
+	}
+	want, err := cm.At(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := a.tickLineStyle(5).Color; got != want {
+		t.Errorf("unexpected color of middle tick mark: got:%v want:%v", got, want)
+	}
+	if cm.Min() != -1 || cm.Max() != 1 {
+		t.Errorf("color map range modified: got:[%v, %v] want:[-1, 1]", cm.Min(), cm.Max())
+	}
+}
+
+func TestWrapLabel(t *testing.T) {
+	font, err := vg.MakeFont("Times-Roman", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sty := draw.TextStyle{Font: font}
+	for _, test := range []struct {
+		s     string
+		width vg.Length
+		want  string
+	}{
+		{s: "short", width: 100, want: "short"},
+		{s: "a long category name", width: sty.Width("a long"), want: "a long\ncategory\nname"},
+		{s: "a long category name", width: sty.Width("a long category name"), want: "a long category name"},
+		{s: "unbreakable", width: 1, want: "unbreakable"},
+		{s: "kept\nbreak here", width: sty.Width("break"), want: "kept\nbreak\nhere"},
+	} {
+		if got := wrapLabel(sty, test.s, test.width); got != test.want {
+			t.Errorf("unexpected wrapping of %q to %v: got:%q want:%q", test.s, test.width, got, test.want)
+		}
+	}
+}
+
+func TestWrapTicks(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.NominalX("first long category", "second long category", "third")
+	p.X.Min, p.X.Max = 0, 2
+	p.Y.Min, p.Y.Max = 0, 1
+	c := draw.NewCanvas(new(recorder.Canvas), 150, 150)
+
+	x, _, _, plain := p.layoutAxes(c)
+	for _, tk := range x.Tick.Marker.Ticks(x.Min, x.Max) {
+		if strings.Contains(tk.Label, "\n") {
+			t.Errorf("unexpected wrapped label without Wrap: %q", tk.Label)
+		}
+	}
+
+	p.X.Tick.Wrap = true
+	x, _, _, wrapped := p.layoutAxes(c)
+	var labels []string
+	for _, tk := range x.Tick.Marker.Ticks(x.Min, x.Max) {
+		labels = append(labels, tk.Label)
+	}
+	want := []string{"first long\ncategory", "second\nlong\ncategory", "third"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("unexpected wrapped labels: got:%q want:%q", labels, want)
+	}
+	if wrapped <= plain {
+		t.Errorf("axis height not increased by wrapping: got:%v plain:%v", wrapped, plain)
+	}
+}
+
+func TestZeroRangePadding(t *testing.T) {
+	for _, test := range []struct {
+		value    float64
+		padding  float64
+		relative bool
+		wantMin  float64
+		wantMax  float64
+	}{
+		{value: 3, wantMin: 2, wantMax: 4},
+		{value: 3, padding: 0.5, wantMin: 2.5, wantMax: 3.5},
+		{value: -200, padding: 0.05, relative: true, wantMin: -210, wantMax: -190},
+		{value: 0, padding: 0.05, relative: true, wantMin: -1, wantMax: 1},
+	} {
+		a, err := makeAxis(horizontal)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		a.Min, a.Max = test.value, test.value
+		a.ZeroRangePadding = test.padding
+		a.ZeroRangeRelative = test.relative
+		a.sanitizeRange()
+		if a.Min != test.wantMin || a.Max != test.wantMax {
+			t.Errorf("unexpected range for %v with padding %v (relative=%t): got:[%v, %v] want:[%v, %v]",
+				test.value, test.padding, test.relative, a.Min, a.Max, test.wantMin, test.wantMax)
+		}
+		if got := a.Norm(test.value); got != 0.5 {
+			t.Errorf("unexpected normalized value for %v: got:%v want:0.5", test.value, got)
+		}
+	}
+
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 4, 4
+	if got := a.Norm(4); got != 0.5 {
+		t.Errorf("unexpected normalized value for empty range: got:%v want:0.5", got)
+	}
+}
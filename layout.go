@@ -0,0 +1,274 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"reflect"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// layoutCache is an axis layout retained between
+// draws of a plot with CacheLayout set.
+type layoutCache struct {
+	key layoutKey
+
+	// xTicks and yTicks are the tick marks
+	// of the horizontal and vertical axes.
+	xTicks, yTicks ConstantTicks
+
+	// ywidth and xheight are the width of the
+	// vertical axis and the height of the
+	// horizontal axis.
+	ywidth, xheight vg.Length
+}
+
+// layoutKey holds the values that a layoutCache
+// depends on.
+type layoutKey struct {
+	rect vg.Rectangle
+	x, y axisKey
+}
+
+// axisKey holds the fields of an Axis that
+// affect its tick marks or its size.
+type axisKey struct {
+	min, max float64
+
+	label           string
+	labelStyle      draw.TextStyle
+	labelHorizontal bool
+	labelPadding    vg.Length
+
+	width, padding vg.Length
+
+	tickStyle        draw.TextStyle
+	tickWidth        vg.Length
+	tickLength       vg.Length
+	tickLabelPadding vg.Length
+	tickStacked      bool
+	tickWrap         bool
+	marker           Ticker
+
+	groupHeight vg.Length
+}
+
+// newAxisKey returns the axisKey for the axis. The
+// returned bool is false if the axis' tick Marker or
+// the style of its labels is not comparable, and so
+// the axis can not be cached.
+func newAxisKey(a Axis) (axisKey, bool) {
+	if a.Tick.Marker == nil || !reflect.ValueOf(a.Tick.Marker).Comparable() {
+		return axisKey{}, false
+	}
+	if !comparableStyle(a.Label.TextStyle) || !comparableStyle(a.Tick.Label) {
+		return axisKey{}, false
+	}
+	return axisKey{
+		min:              a.Min,
+		max:              a.Max,
+		label:            a.Label.Text,
+		labelStyle:       a.Label.TextStyle,
+		labelHorizontal:  a.Label.Horizontal,
+		labelPadding:     a.Label.Padding,
+		width:            a.Width,
+		padding:          a.Padding,
+		tickStyle:        a.Tick.Label,
+		tickWidth:        a.Tick.Width,
+		tickLength:       a.Tick.Length,
+		tickLabelPadding: a.Tick.LabelPadding,
+		tickStacked:      a.Tick.Stacked,
+		tickWrap:         a.Tick.Wrap,
+		marker:           a.Tick.Marker,
+		groupHeight:      horizontalAxis{a}.groupHeight(),
+	}, true
+}
+
+// comparableStyle returns whether the text style
+// can be compared with ==, which is not the case
+// when its Color has a dynamic type that is not
+// comparable.
+func comparableStyle(sty draw.TextStyle) bool {
+	return sty.Color == nil || reflect.ValueOf(sty.Color).Comparable()
+}
+
+// resolveAxes returns the axes of the plot with any relative
+// tick dimensions resolved for the data area of the given draw
+// area, excluding the title.  Since the size of the data area
+// depends on those of the axes, it is found by successive
+// refinement, starting from the size of the draw area.
+func (p *Plot) resolveAxes(c draw.Canvas) (horizontalAxis, verticalAxis) {
+	size := minDimension(c)
+	x := horizontalAxis{p.X.resolve(size)}
+	y := verticalAxis{p.Y.resolve(size)}
+	if !p.X.relativeTicks() && !p.Y.relativeTicks() {
+		return x, y
+	}
+	for i := 0; i < 20; i++ {
+		xs := x
+		if xs.Tick.Wrap {
+			xs.Axis = xs.wrapTicks(c.Max.X - c.Min.X - y.size())
+		}
+		da := draw.Crop(c, y.size(), 0, xs.size(), 0)
+		left, right, bottom, top := p.legendInsets(da)
+		da = padY(p, y, padX(p, x, draw.Crop(da, left, -right, bottom, -top)))
+		s := vg.Length(math.Max(0, float64(minDimension(da))))
+		if math.Abs(float64(s-size)) < canvasSizeTolerance {
+			break
+		}
+		size = s
+		x = horizontalAxis{p.X.resolve(size)}
+		y = verticalAxis{p.Y.resolve(size)}
+	}
+	return x, y
+}
+
+// layoutAxes returns the axes of the plot resolved for
+// the given draw area, excluding the title, along with
+// the width of the vertical axis and the height of the
+// horizontal axis.  If CacheLayout is set, the tick
+// marks and sizes are reused from the previous call
+// when they are unchanged.
+func (p *Plot) layoutAxes(c draw.Canvas) (x horizontalAxis, y verticalAxis, ywidth, xheight vg.Length) {
+	x, y = p.resolveAxes(c)
+
+	// The key is built from the axes before their
+	// tick labels are wrapped, since the wrapped
+	// ticks are not comparable.
+	var key layoutKey
+	cache := p.CacheLayout
+	if cache {
+		xKey, xOK := newAxisKey(x.Axis)
+		yKey, yOK := newAxisKey(y.Axis)
+		cache = xOK && yOK
+		key = layoutKey{rect: c.Rectangle, x: xKey, y: yKey}
+	}
+	if cache && p.layout != nil && p.layout.key == key {
+		x.Tick.Marker = p.layout.xTicks
+		y.Tick.Marker = p.layout.yTicks
+		return x, y, p.layout.ywidth, p.layout.xheight
+	}
+
+	if x.Tick.Wrap {
+		x.Axis = x.wrapTicks(c.Max.X - c.Min.X - y.size())
+	}
+	if !cache {
+		p.layout = nil
+		return x, y, y.size(), x.size()
+	}
+
+	l := &layoutCache{
+		key:    key,
+		xTicks: x.Tick.Marker.Ticks(x.Min, x.Max),
+		yTicks: y.Tick.Marker.Ticks(y.Min, y.Max),
+	}
+	x.Tick.Marker = l.xTicks
+	y.Tick.Marker = l.yTicks
+	l.ywidth = y.size()
+	l.xheight = x.size()
+	p.layout = l
+	return x, y, l.ywidth, l.xheight
+}
+
+// Layout describes the layout of a plot on a canvas,
+// as it is computed when the plot is drawn.
+type Layout struct {
+	// DataArea is the area of the canvas that
+	// the plotters are drawn to.
+	DataArea vg.Rectangle
+
+	// X and Y describe the horizontal and
+	// vertical axes respectively.
+	X, Y AxisLayout
+
+	// Legends describe Legend and Legends, in
+	// order, omitting legends without entries.
+	Legends []LegendLayout
+}
+
+// AxisLayout describes an axis of a plot.
+type AxisLayout struct {
+	// Min and Max are the range of the axis.
+	Min, Max float64
+
+	// Label is the text of the axis label.
+	Label string
+
+	// Ticks are the tick marks within the range
+	// of the axis.  Minor ticks have no label.
+	Ticks []Tick
+}
+
+// LegendLayout describes a legend of a plot.
+type LegendLayout struct {
+	// Rectangle is the area of the canvas
+	// that the legend is drawn to.
+	Rectangle vg.Rectangle
+
+	// Entries are the names of the legend
+	// entries, in order.
+	Entries []string
+}
+
+// Layout returns the layout of the plot when it is drawn to
+// the canvas, without drawing it.  The layout may be used to
+// check the placement of the parts of a plot, or to describe
+// a plot in text.  As when drawing, the axis ranges are first
+// sanitized and equalized if EqualScale is set.  The ranges
+// of the returned axis layouts are the equalized ranges.
+func (p *Plot) Layout(c draw.Canvas) Layout {
+	c = draw.Crop(c, p.Margin, -p.Margin, p.Margin, -p.Margin)
+	c.Max.Y -= p.titleHeight()
+	c, outside := p.outsideLegends(c)
+
+	p.X.sanitizeRange()
+	p.Y.sanitizeRange()
+	if p.EqualScale {
+		defer p.equalizeScale(c)()
+	}
+	x, y, ywidth, xheight := p.layoutAxes(c)
+	legendC := draw.Crop(c, ywidth, 0, xheight, 0)
+	left, right, bottom, top := p.legendInsets(legendC)
+	l := Layout{
+		DataArea: padY(p, y, padX(p, x, draw.Crop(legendC, left, -right, bottom, -top))).Rectangle,
+		X:        newAxisLayout(x.Axis),
+		Y:        newAxisLayout(y.Axis),
+	}
+	placed := make(map[*Legend]draw.Canvas)
+	p.placeLegends(legendC, func(lg *Legend, lc draw.Canvas) {
+		placed[lg] = lc
+	})
+	for lg, lc := range outside {
+		placed[lg] = lc
+	}
+	for _, lg := range p.legends() {
+		lc, ok := placed[lg]
+		if !ok {
+			continue
+		}
+		r := lg.Rectangle(lc)
+		offs := vg.Point{X: lg.XOffs, Y: lg.YOffs}
+		ll := LegendLayout{Rectangle: vg.Rectangle{Min: r.Min.Add(offs), Max: r.Max.Add(offs)}}
+		for _, e := range lg.entries {
+			ll.Entries = append(ll.Entries, e.text)
+		}
+		l.Legends = append(l.Legends, ll)
+	}
+	return l
+}
+
+// newAxisLayout returns the AxisLayout of a resolved axis.
+func newAxisLayout(a Axis) AxisLayout {
+	l := AxisLayout{Min: a.Min, Max: a.Max, Label: a.Label.Text}
+	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+		if t.Value < a.Min || a.Max < t.Value {
+			continue
+		}
+		l.Ticks = append(l.Ticks, t)
+	}
+	return l
+}
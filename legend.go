@@ -5,6 +5,9 @@
 package plot
 
 import (
+	"errors"
+	"image/color"
+	"io"
 	"math"
 
 	"gonum.org/v1/plot/vg"
@@ -43,6 +46,41 @@ type Legend struct {
 	// ThumbnailWidth is the width of legend thumbnails.
 	ThumbnailWidth vg.Length
 
+	// ThumbnailHeight is the height of legend thumbnails.
+	// If ThumbnailHeight is zero then thumbnails are the
+	// height of the tallest legend entry text.  Entries
+	// are made tall enough to hold their thumbnails.
+	ThumbnailHeight vg.Length
+
+	// Horizontal specifies that the legend entries
+	// are laid out from left to right in rows rather
+	// than in a single column.
+	Horizontal bool
+
+	// Columns is the number of entries in each row
+	// of a horizontal legend.  If Columns is zero then
+	// all of the entries are placed in a single row.
+	Columns int
+
+	// Inset specifies that the data area of the plot
+	// is reduced to make room for the legend, so that
+	// the plotters are not drawn under it.  A vertical
+	// legend insets the data area on its left or right
+	// side and a horizontal legend insets it on its top
+	// or bottom side.  If Inset is false the legend is
+	// drawn over the data area.
+	Inset bool
+
+	// Outside specifies that the legend is drawn outside
+	// of the axes, in space reserved for it along an edge
+	// of the plot, rather than over the data area.  A
+	// horizontal legend is placed below the horizontal
+	// axis, or above the data area if Top is set.  A
+	// vertical legend is placed to the right of the data
+	// area, or to the left of the vertical axis if Left is
+	// set.  Inset has no effect on a legend drawn Outside.
+	Outside bool
+
 	// entries are all of the legendEntries described
 	// by this legend.
 	entries []legendEntry
@@ -69,6 +107,19 @@ type Thumbnailer interface {
 	Thumbnail(c *draw.Canvas)
 }
 
+// LegendEntryer wraps the Thumbnail and LegendName
+// methods.  Plotters that implement LegendEntryer
+// can be added to a legend automatically by calling
+// the Plot's AutoLegend method.
+type LegendEntryer interface {
+	Thumbnailer
+
+	// LegendName returns the name of the legend
+	// entry.  If LegendName returns the empty string
+	// then no legend entry is added.
+	LegendName() string
+}
+
 // NewLegend returns a legend with the default
 // parameter settings.
 func NewLegend() (Legend, error) {
@@ -84,6 +135,10 @@ func NewLegend() (Legend, error) {
 
 // Draw draws the legend to the given draw.Canvas.
 func (l *Legend) Draw(c draw.Canvas) {
+	if l.Horizontal {
+		l.drawRows(c)
+		return
+	}
 	iconx := c.Min.X
 	sty := l.TextStyle
 	textx := iconx + l.ThumbnailWidth + sty.Rectangle(" ").Max.X
@@ -102,11 +157,15 @@ func (l *Legend) Draw(c draw.Canvas) {
 	}
 	y += l.YOffs
 
+	thumbh := l.ThumbnailHeight
+	if thumbh == 0 {
+		thumbh = enth
+	}
 	icon := &draw.Canvas{
-		Canvas: c.Canvas,
+		Canvas: c,
 		Rectangle: vg.Rectangle{
-			Min: vg.Point{X: iconx, Y: y},
-			Max: vg.Point{X: iconx + l.ThumbnailWidth, Y: y + enth},
+			Min: vg.Point{X: iconx, Y: y + (enth-thumbh)/2},
+			Max: vg.Point{X: iconx + l.ThumbnailWidth, Y: y + (enth+thumbh)/2},
 		},
 	}
 	for _, e := range l.entries {
@@ -114,10 +173,100 @@ func (l *Legend) Draw(c draw.Canvas) {
 			t.Thumbnail(icon)
 		}
 		yoffs := (enth - sty.Rectangle(e.text).Max.Y) / 2
-		c.FillText(sty, vg.Point{X: textx, Y: icon.Min.Y + yoffs}, e.text)
+		c.FillText(sty, vg.Point{X: textx, Y: y + yoffs}, e.text)
 		icon.Min.Y -= enth + l.Padding
 		icon.Max.Y -= enth + l.Padding
+		y -= enth + l.Padding
+	}
+}
+
+// WriterTo returns an io.WriterTo that will write only
+// the legend as the specified image format, sized to the
+// extent of the legend, so that it can be placed apart
+// from any plot.  The supported formats are those of
+// Plot.WriterTo.  XOffs and YOffs are ignored.  An error
+// is returned if the legend has no entries.
+func (l *Legend) WriterTo(format string) (io.WriterTo, error) {
+	if len(l.entries) == 0 {
+		return nil, errors.New("plot: legend has no entries")
+	}
+	size := l.Rectangle(draw.Canvas{}).Size()
+	c, err := draw.NewFormattedCanvas(size.X, size.Y, format)
+	if err != nil {
+		return nil, err
 	}
+	lg := *l
+	lg.XOffs, lg.YOffs = 0, 0
+	lg.Draw(draw.New(c))
+	return c, nil
+}
+
+// drawRows draws the entries of a horizontal
+// legend to the given draw.Canvas.
+func (l *Legend) drawRows(c draw.Canvas) {
+	r := l.Rectangle(c)
+	sty := l.TextStyle
+	space := sty.Rectangle(" ").Max.X
+	if !l.Left {
+		sty.XAlign--
+	}
+	enth := l.entryHeight()
+	thumbh := l.ThumbnailHeight
+	if thumbh == 0 {
+		thumbh = enth
+	}
+	widths := l.columnWidths()
+	gap := l.columnGap()
+	for i, e := range l.entries {
+		row, col := i/len(widths), i%len(widths)
+		x := r.Min.X + l.XOffs + gap*vg.Length(col)
+		for _, w := range widths[:col] {
+			x += w
+		}
+		y := r.Max.Y + l.YOffs - enth*vg.Length(row+1) - l.Padding*vg.Length(row)
+
+		iconx := x
+		textx := iconx + l.ThumbnailWidth + space
+		if !l.Left {
+			iconx = x + widths[col] - l.ThumbnailWidth
+			textx = iconx - space
+		}
+		icon := &draw.Canvas{
+			Canvas: c,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: iconx, Y: y + (enth-thumbh)/2},
+				Max: vg.Point{X: iconx + l.ThumbnailWidth, Y: y + (enth+thumbh)/2},
+			},
+		}
+		for _, t := range e.thumbs {
+			t.Thumbnail(icon)
+		}
+		yoffs := (enth - sty.Rectangle(e.text).Max.Y) / 2
+		c.FillText(sty, vg.Point{X: textx, Y: y + yoffs}, e.text)
+	}
+}
+
+// columnWidths returns the width of each column
+// of a horizontal legend.
+func (l *Legend) columnWidths() []vg.Length {
+	n := l.Columns
+	if n <= 0 || n > len(l.entries) {
+		n = len(l.entries)
+	}
+	widths := make([]vg.Length, n)
+	for i, e := range l.entries {
+		w := l.ThumbnailWidth + l.TextStyle.Rectangle(" "+e.text).Max.X
+		if w > widths[i%n] {
+			widths[i%n] = w
+		}
+	}
+	return widths
+}
+
+// columnGap returns the horizontal space between
+// the columns of a horizontal legend.
+func (l *Legend) columnGap() vg.Length {
+	return l.TextStyle.Rectangle("  ").Max.X + l.Padding
 }
 
 // Rectangle returns the extent of the Legend.
@@ -125,11 +274,25 @@ func (l *Legend) Rectangle(c draw.Canvas) vg.Rectangle {
 	var width, height vg.Length
 	sty := l.TextStyle
 	entryHeight := l.entryHeight()
-	for i, e := range l.entries {
-		width = vg.Length(math.Max(float64(width), float64(l.ThumbnailWidth+sty.Rectangle(" "+e.text).Max.X)))
-		height += entryHeight
-		if i != 0 {
-			height += l.Padding
+	if l.Horizontal {
+		widths := l.columnWidths()
+		for i, w := range widths {
+			width += w
+			if i != 0 {
+				width += l.columnGap()
+			}
+		}
+		if len(widths) > 0 {
+			rows := (len(l.entries) + len(widths) - 1) / len(widths)
+			height = entryHeight*vg.Length(rows) + l.Padding*vg.Length(rows-1)
+		}
+	} else {
+		for i, e := range l.entries {
+			width = vg.Length(math.Max(float64(width), float64(l.ThumbnailWidth+sty.Rectangle(" "+e.text).Max.X)))
+			height += entryHeight
+			if i != 0 {
+				height += l.Padding
+			}
 		}
 	}
 	var r vg.Rectangle
@@ -151,13 +314,16 @@ func (l *Legend) Rectangle(c draw.Canvas) vg.Rectangle {
 }
 
 // entryHeight returns the height of the tallest legend
-// entry text.
+// entry text, or the thumbnail height if it is greater.
 func (l *Legend) entryHeight() (height vg.Length) {
 	for _, e := range l.entries {
 		if h := l.TextStyle.Rectangle(e.text).Max.Y; h > height {
 			height = h
 		}
 	}
+	if l.ThumbnailHeight > height {
+		height = l.ThumbnailHeight
+	}
 	return
 }
 
@@ -167,3 +333,45 @@ func (l *Legend) entryHeight() (height vg.Length) {
 func (l *Legend) Add(name string, thumbs ...Thumbnailer) {
 	l.entries = append(l.entries, legendEntry{text: name, thumbs: thumbs})
 }
+
+// AddPlotters adds an entry to the legend with the given
+// name whose thumbnail is the composite of the thumbnails
+// of the plotters, so that the entry matches the plotters'
+// appearance.  Plotters that do not implement Thumbnailer
+// are represented by a filled box, drawn in the plotter's
+// color if the plotter implements color.Color, or in
+// DefaultThumbnailColor otherwise.
+func (l *Legend) AddPlotters(name string, plotters ...Plotter) {
+	thumbs := make([]Thumbnailer, len(plotters))
+	for i, p := range plotters {
+		switch p := p.(type) {
+		case Thumbnailer:
+			thumbs[i] = p
+		case color.Color:
+			thumbs[i] = boxThumbnailer{p}
+		default:
+			thumbs[i] = boxThumbnailer{DefaultThumbnailColor}
+		}
+	}
+	l.Add(name, thumbs...)
+}
+
+// DefaultThumbnailColor is the color of the box used by
+// Legend.AddPlotters to represent plotters that do not
+// implement Thumbnailer.
+var DefaultThumbnailColor color.Color = color.Gray{Y: 128}
+
+// boxThumbnailer draws a filled box as a thumbnail.
+type boxThumbnailer struct {
+	color.Color
+}
+
+// Thumbnail implements the Thumbnailer interface.
+func (t boxThumbnailer) Thumbnail(c *draw.Canvas) {
+	c.FillPolygon(t.Color, []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	})
+}
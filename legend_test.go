@@ -5,13 +5,17 @@
 package plot
 
 import (
+	"bytes"
+	"image"
 	"image/color"
+	"image/png"
 	"os"
 	"testing"
 
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
 	"gonum.org/v1/plot/vg/vgimg"
 )
 
@@ -99,3 +103,260 @@ func ExampleLegend_standalone() {
 func TestLegend_standalone(t *testing.T) {
 	cmpimg.CheckPlot(ExampleLegend_standalone, t, "legend_standalone.png")
 }
+
+// This example writes a legend to its own image, sized to fit.
+func ExampleLegend_WriterTo() {
+	red := exampleThumbnailer{Color: color.NRGBA{R: 255, A: 255}}
+	blue := exampleThumbnailer{Color: color.NRGBA{B: 255, A: 255}}
+
+	l, err := NewLegend()
+	if err != nil {
+		panic(err)
+	}
+	l.Add("red", red)
+	l.Add("blue", blue)
+	l.Left = true
+
+	wt, err := l.WriterTo("png")
+	if err != nil {
+		panic(err)
+	}
+	w, err := os.Create("testdata/legend_writerTo.png")
+	if err != nil {
+		panic(err)
+	}
+	defer w.Close()
+	if _, err := wt.WriteTo(w); err != nil {
+		panic(err)
+	}
+}
+
+func TestLegendWriterTo(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLegend_WriterTo, t, "legend_writerTo.png")
+
+	l, err := NewLegend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := l.WriterTo("png"); err == nil {
+		t.Errorf("expected error for legend without entries")
+	}
+
+	l.Add("entry", exampleThumbnailer{Color: color.Black})
+	l.Add("another entry", exampleThumbnailer{Color: color.Black})
+	wt, err := l.WriterTo("png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := wt.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	size := l.Rectangle(draw.Canvas{}).Size()
+	want := image.Pt(int(size.X.Dots(vgimg.DefaultDPI)+0.5), int(size.Y.Dots(vgimg.DefaultDPI)+0.5))
+	if got := img.Bounds().Size(); got != want {
+		t.Errorf("unexpected image size: got:%v want:%v", got, want)
+	}
+}
+
+type namedPlotter struct {
+	exampleThumbnailer
+	name string
+}
+
+func (namedPlotter) Plot(draw.Canvas, *Plot) {}
+
+// LegendName fulfills the plot.LegendEntryer interface.
+func (np namedPlotter) LegendName() string { return np.name }
+
+type unnamedPlotter struct{}
+
+func (unnamedPlotter) Plot(draw.Canvas, *Plot) {}
+
+func TestAutoLegend(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(
+		namedPlotter{name: "first"},
+		unnamedPlotter{},
+		namedPlotter{name: ""},
+		namedPlotter{name: "second"},
+	)
+	p.AutoLegend()
+
+	var got []string
+	for _, e := range p.Legend.entries {
+		got = append(got, e.text)
+	}
+	want := []string{"first", "second"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected legend entries: got:%q want:%q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("unexpected legend entry %d: got:%q want:%q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLegendThumbnailHeight(t *testing.T) {
+	l, err := NewLegend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Add("A", exampleThumbnailer{Color: color.Black})
+	l.Add("B", exampleThumbnailer{Color: color.Black})
+	l.Padding = 2
+
+	var c draw.Canvas
+	c.Max = vg.Point{X: 100, Y: 100}
+	textHeight := l.entryHeight()
+	if got, want := l.Rectangle(c).Size().Y, 2*textHeight+l.Padding; got != want {
+		t.Errorf("unexpected legend height with default thumbnails: got:%v want:%v", got, want)
+	}
+
+	l.ThumbnailHeight = textHeight / 2
+	if got, want := l.Rectangle(c).Size().Y, 2*textHeight+l.Padding; got != want {
+		t.Errorf("unexpected legend height with short thumbnails: got:%v want:%v", got, want)
+	}
+
+	l.ThumbnailHeight = 30
+	if got, want := l.Rectangle(c).Size().Y, 2*l.ThumbnailHeight+l.Padding; got != want {
+		t.Errorf("unexpected legend height with tall thumbnails: got:%v want:%v", got, want)
+	}
+}
+
+// rectThumbnailer records the canvas rectangle
+// given to its Thumbnail method.
+type rectThumbnailer struct {
+	rects *[]vg.Rectangle
+}
+
+func (t rectThumbnailer) Thumbnail(c *draw.Canvas) {
+	*t.rects = append(*t.rects, c.Rectangle)
+}
+
+func TestLegendHorizontal(t *testing.T) {
+	l, err := NewLegend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rects []vg.Rectangle
+	for _, name := range []string{"A", "B", "C"} {
+		l.Add(name, rectThumbnailer{rects: &rects})
+	}
+	l.Horizontal = true
+	l.Left = true
+	l.Top = true
+	l.Padding = 2
+
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 200, 100)
+	enth := l.entryHeight()
+
+	if got, want := l.Rectangle(c).Size().Y, enth; got != want {
+		t.Errorf("unexpected single row height: got:%v want:%v", got, want)
+	}
+	l.Draw(c)
+	if len(rects) != 3 {
+		t.Fatalf("unexpected number of thumbnails: got:%d want:3", len(rects))
+	}
+	for i := 1; i < len(rects); i++ {
+		if rects[i].Min.Y != rects[0].Min.Y {
+			t.Errorf("thumbnail %d not in first row: got y=%v want y=%v", i, rects[i].Min.Y, rects[0].Min.Y)
+		}
+		if rects[i].Min.X <= rects[i-1].Max.X {
+			t.Errorf("thumbnail %d overlaps thumbnail %d", i, i-1)
+		}
+	}
+
+	rects = rects[:0]
+	l.Columns = 2
+	if got, want := l.Rectangle(c).Size().Y, 2*enth+l.Padding; got != want {
+		t.Errorf("unexpected wrapped height: got:%v want:%v", got, want)
+	}
+	l.Draw(c)
+	if len(rects) != 3 {
+		t.Fatalf("unexpected number of thumbnails: got:%d want:3", len(rects))
+	}
+	if rects[1].Min.Y != rects[0].Min.Y {
+		t.Errorf("second thumbnail not in first row")
+	}
+	if got, want := rects[2].Min.Y, rects[0].Min.Y-enth-l.Padding; got != want {
+		t.Errorf("unexpected second row position: got:%v want:%v", got, want)
+	}
+	if rects[2].Min.X != rects[0].Min.X {
+		t.Errorf("second row not aligned with first column: got x=%v want x=%v", rects[2].Min.X, rects[0].Min.X)
+	}
+}
+
+func TestMultipleLegends(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.HideAxes()
+	p.Legend.Top = true
+	p.Legend.Add("color", exampleThumbnailer{Color: color.Black})
+	shape, err := NewLegend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	shape.Top = true
+	shape.Add("shape", exampleThumbnailer{Color: color.Black})
+	p.Legends = append(p.Legends, shape)
+
+	var r recorder.Canvas
+	p.Draw(draw.NewCanvas(&r, 100, 100))
+
+	pos := make(map[string]vg.Point)
+	for _, a := range r.Actions {
+		if fs, ok := a.(*recorder.FillString); ok {
+			pos[fs.String] = fs.Point
+		}
+	}
+	c, s := pos["color"], pos["shape"]
+	if gap := c.Y - s.Y; gap < p.Legend.entryHeight() {
+		t.Errorf("legends overlap: got vertical separation %v", gap)
+	}
+}
+
+type colorPlotter struct {
+	color.Color
+}
+
+func (colorPlotter) Plot(draw.Canvas, *Plot) {}
+
+func TestLegendAddPlotters(t *testing.T) {
+	l, err := NewLegend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	red := color.NRGBA{R: 255, A: 255}
+	named := namedPlotter{exampleThumbnailer: exampleThumbnailer{Color: color.Black}, name: "a"}
+	colored := colorPlotter{red}
+	l.AddPlotters("entry", named, unnamedPlotter{}, colored)
+
+	if len(l.entries) != 1 {
+		t.Fatalf("unexpected number of entries: got:%d want:1", len(l.entries))
+	}
+	thumbs := l.entries[0].thumbs
+	if len(thumbs) != 3 {
+		t.Fatalf("unexpected number of thumbnails: got:%d want:3", len(thumbs))
+	}
+	if thumbs[0] != Thumbnailer(named) {
+		t.Errorf("plotter thumbnail not used: got:%#v", thumbs[0])
+	}
+	if got, want := thumbs[1], (boxThumbnailer{DefaultThumbnailColor}); got != Thumbnailer(want) {
+		t.Errorf("unexpected fallback thumbnail: got:%#v want:%#v", got, want)
+	}
+	if got, want := thumbs[2], (boxThumbnailer{colored}); got != Thumbnailer(want) {
+		t.Errorf("unexpected colored fallback thumbnail: got:%#v want:%#v", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"errors"
+	"image/color"
+	"math"
+)
+
+// Log returns a ColorMap that maps values to the colors of
+// ColorMap c by their logarithm, so that values spanning
+// several orders of magnitude are spread evenly across the
+// colors.  The minimum and maximum of the returned ColorMap
+// are shared with c and must be positive.  Non-positive
+// values can not be mapped, and At returns an error for
+// them.
+func Log(c ColorMap) ColorMap {
+	return logarithmic{ColorMap: c}
+}
+
+// logarithmic is a ColorMap that maps values by
+// their logarithm to the ColorMap it contains.
+type logarithmic struct {
+	ColorMap
+}
+
+// At implements the ColorMap interface for a logarithmic ColorMap.
+func (l logarithmic) At(v float64) (color.Color, error) {
+	min, max := l.Min(), l.Max()
+	if min <= 0 || max <= 0 {
+		return nil, errors.New("palette: non-positive range for logarithmic ColorMap")
+	}
+	if v <= 0 {
+		return nil, errors.New("palette: non-positive value for logarithmic ColorMap")
+	}
+	if max == min {
+		return l.ColorMap.At(v)
+	}
+	// Map v onto the range of c at the same
+	// fraction of the logarithmic range.
+	f := math.Log(v/min) / math.Log(max/min)
+	return l.ColorMap.At(min + f*(max-min))
+}
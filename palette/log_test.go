@@ -0,0 +1,60 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"testing"
+)
+
+// grayMap is a linear ColorMap from black to white.
+type grayMap struct {
+	min, max float64
+}
+
+func (m *grayMap) At(v float64) (color.Color, error) {
+	if v < m.min || v > m.max {
+		return nil, ErrOverflow
+	}
+	return color.Gray{Y: uint8(255*(v-m.min)/(m.max-m.min) + 0.5)}, nil
+}
+func (m *grayMap) Max() float64               { return m.max }
+func (m *grayMap) SetMax(v float64)           { m.max = v }
+func (m *grayMap) Min() float64               { return m.min }
+func (m *grayMap) SetMin(v float64)           { m.min = v }
+func (m *grayMap) Alpha() float64             { return 1 }
+func (m *grayMap) SetAlpha(float64)           {}
+func (m *grayMap) Palette(colors int) Palette { return nil }
+
+func TestLog(t *testing.T) {
+	cm := Log(&grayMap{})
+	cm.SetMin(1)
+	cm.SetMax(100)
+	for _, test := range []struct {
+		v    float64
+		want uint8
+	}{
+		{v: 1, want: 0},
+		{v: 10, want: 128},
+		{v: 100, want: 255},
+	} {
+		c, err := cm.At(test.v)
+		if err != nil {
+			t.Errorf("unexpected error for %v: %v", test.v, err)
+			continue
+		}
+		if got := c.(color.Gray).Y; got != test.want {
+			t.Errorf("unexpected color for %v: got:%d want:%d", test.v, got, test.want)
+		}
+	}
+	for _, v := range []float64{0, -1} {
+		if _, err := cm.At(v); err == nil {
+			t.Errorf("expected error for non-positive value %v", v)
+		}
+	}
+	if _, err := cm.At(1000); err == nil {
+		t.Errorf("expected error for value above range")
+	}
+}
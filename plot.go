@@ -5,15 +5,22 @@
 package plot
 
 import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
 	"image/color"
 	"io"
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
 )
 
 var (
@@ -34,13 +41,61 @@ type Plot struct {
 		// the top of the plot.
 		Padding vg.Length
 
+		// BackgroundColor, if non-nil, is the color
+		// of a box that is filled behind the title.
+		BackgroundColor color.Color
+
+		// Border is the style of the line drawn
+		// around the title box.  No border is drawn
+		// if its Width is zero.
+		Border draw.LineStyle
+
+		// BoxPadding is the amount of padding between
+		// the title text and the edges of its box.
+		// It is only used if the title has a
+		// background or a border.
+		BoxPadding vg.Length
+
 		draw.TextStyle
 	}
 
 	// BackgroundColor is the background color of the plot.
-	// The default is White.
+	// The default is White.  If BackgroundColor is nil then
+	// the background is transparent in the formats that
+	// support transparency; jpg images are always opaque.
 	BackgroundColor color.Color
 
+	// DataBackgroundColor, if not nil, is the background
+	// color of the data area, which is then not filled
+	// with BackgroundColor.  A DataBackgroundColor of
+	// color.Transparent gives a transparent data area
+	// within opaque margins, allowing content beneath the
+	// plot to show through the plotted region only.
+	DataBackgroundColor color.Color
+
+	// Frame holds the styles of the lines drawn along
+	// each side of the data area of the plot.  A side is
+	// not drawn if the Width of its style is zero, so any
+	// subset of the sides may be drawn, for example only
+	// Left and Bottom for a minimal frame.  The frame does
+	// not change the space reserved for the axes.
+	Frame struct {
+		Top, Bottom, Left, Right draw.LineStyle
+	}
+
+	// Margin is the width of the margin around the
+	// edges of the canvas that the plot is drawn to.
+	// The background fills the margin, but nothing
+	// else is drawn within it.
+	Margin vg.Length
+
+	// GlyphPadding is extra space left between the
+	// glyphs of the plotters and the edges of the data
+	// area, beyond what is needed to keep the glyphs
+	// from being clipped.  If GlyphPadding is zero the
+	// data area hugs the outermost glyphs.
+	GlyphPadding vg.Length
+
 	// X and Y are the horizontal and vertical axes
 	// of the plot respectively.
 	X, Y Axis
@@ -48,6 +103,74 @@ type Plot struct {
 	// Legend is the plot's legend.
 	Legend Legend
 
+	// Legends are additional legends drawn after Legend,
+	// for example to describe the colors and the glyph
+	// shapes of the data separately.  A legend may be
+	// given a heading by adding an entry without any
+	// thumbnails.  Legends that are placed in the same
+	// corner of the plot are stacked away from the
+	// corner, in order, so that they do not overlap.
+	Legends []Legend
+
+	// EqualScale specifies that, when the plot is drawn,
+	// the range of one of the axes is expanded so that a
+	// data unit has the same length along both axes.  The
+	// original range of the expanded axis is centered in
+	// its new range.  The expanded range is used only
+	// while the plot is drawn, and the Min and Max fields
+	// of the axes are left unchanged.  EqualScale is
+	// intended for use with linear axis scales.
+	EqualScale bool
+
+	// DefaultLineStyle and DefaultGlyphStyle are the line
+	// and glyph styles given to plotters by the
+	// plotter.ApplyPlotStyles function, for giving the
+	// plotters of a plot a consistent appearance.  A style
+	// whose Color is nil is not applied.
+	DefaultLineStyle  draw.LineStyle
+	DefaultGlyphStyle draw.GlyphStyle
+
+	// DataSize, if both of its dimensions are non-zero,
+	// specifies the size of the data area of the plot.
+	// WriterTo and Save then ignore the width and height
+	// that they are given, and instead use the canvas
+	// size returned by CanvasSize for DataSize.
+	DataSize vg.Point
+
+	// CacheLayout specifies that the tick marks and sizes
+	// of the axes are retained between draws and reused
+	// while the canvas size, the axis ranges and the axis
+	// settings that affect their layout are unchanged.
+	// This avoids recomputing the layout when a plot whose
+	// data changes is drawn repeatedly, at the cost of
+	// holding the retained layout in memory.  The layout is
+	// only retained for axes whose tick Marker is
+	// comparable.  Markers are compared by value, and
+	// pointer Markers by identity, rather than by the ticks
+	// that they return, so the Markers of a plot with
+	// CacheLayout set must not change their ticks for a
+	// given range.  Assigning a different Marker to an
+	// axis discards its retained layout.
+	CacheLayout bool
+
+	// DataClip, if not nil, returns the path that the
+	// plotters are clipped to, given the canvas of the
+	// data area that they are drawn to, allowing plots
+	// with a circular or other non-rectangular data area.
+	// Plotters drawn to a vg.Canvas that does not
+	// implement vg.Clipper are not clipped.
+	DataClip func(c draw.Canvas) vg.Path
+
+	// Metadata is the document metadata, such as the
+	// title and author, recorded by WriterTo and Save in
+	// the formats that support it, currently pdf and svg.
+	// It is ignored by the other formats.
+	Metadata vg.Metadata
+
+	// layout is the retained axis layout used
+	// when CacheLayout is true.
+	layout *layoutCache
+
 	// plotters are drawn by calling their Plot method
 	// after the axes are drawn.
 	plotters []Plotter
@@ -69,6 +192,18 @@ type DataRanger interface {
 	DataRange() (xmin, xmax, ymin, ymax float64)
 }
 
+// NoRanger wraps the NoRange method.  A Plotter
+// that implements NoRanger and returns true from
+// NoRange does not extend the axes of a plot when
+// it is added, even if it implements DataRanger.
+// This allows annotations and reference lines to
+// be drawn without affecting the axis ranges.
+type NoRanger interface {
+	// NoRange returns whether the plotter is
+	// excluded from fitting the axis ranges.
+	NoRange() bool
+}
+
 const (
 	vertical   = true
 	horizontal = false
@@ -113,24 +248,182 @@ func New() (*Plot, error) {
 // If the plotters implements DataRanger then the
 // minimum and maximum values of the X and Y
 // axes are changed if necessary to fit the range of
-// the data.
+// the data, unless it also implements NoRanger and
+// NoRange returns true.  The ranges are not extended to
+// values outside of the domain of an axis' Scale, such
+// as zero on a LogScale axis, so the Scale should be set
+// before the data is added.  If the data range of a
+// plotter whose data is provided as x, y pairs extends
+// outside of the domain, the least of its values within
+// the domain is used instead.
 //
 // When drawing the plot, Plotters are drawn in the
 // order in which they were added to the plot.
 func (p *Plot) Add(ps ...Plotter) {
 	for _, d := range ps {
+		if n, ok := d.(NoRanger); ok && n.NoRange() {
+			continue
+		}
 		if x, ok := d.(DataRanger); ok {
 			xmin, xmax, ymin, ymax := x.DataRange()
-			p.X.Min = math.Min(p.X.Min, xmin)
-			p.X.Max = math.Max(p.X.Max, xmax)
-			p.Y.Min = math.Min(p.Y.Min, ymin)
-			p.Y.Max = math.Max(p.Y.Max, ymax)
+			if data, ok := d.(xyer); ok {
+				xmin = domainMin(p.X.Scale, xmin, data, func(x, _ float64) float64 { return x })
+				ymin = domainMin(p.Y.Scale, ymin, data, func(_, y float64) float64 { return y })
+			}
+			p.ExtendRange(xmin, xmax, ymin, ymax)
 		}
 	}
 
 	p.plotters = append(p.plotters, ps...)
 }
 
+// domainMin returns min if it is in the domain of the
+// scale s, and otherwise the least value of the data,
+// as returned by v, that is in the domain.
+func domainMin(s Normalizer, min float64, data xyer, v func(x, y float64) float64) float64 {
+	d, ok := s.(DomainNormalizer)
+	if !ok || d.InDomain(min) {
+		return min
+	}
+	min = math.Inf(1)
+	for i := 0; i < data.Len(); i++ {
+		if x := v(data.XY(i)); d.InDomain(x) && x < min {
+			min = x
+		}
+	}
+	return min
+}
+
+// Plotters returns a copy of the plot's Plotters, in
+// the order in which they were added to the plot.
+func (p *Plot) Plotters() []Plotter {
+	return append([]Plotter(nil), p.plotters...)
+}
+
+// ExtendRange changes the minimum and maximum values
+// of the X and Y axes if necessary to include the given
+// range.  It allows the axes to be fitted to data whose
+// range is known in advance, or is computed incrementally,
+// without the data's plotter implementing DataRanger.
+// Values outside of the domain of an axis' Scale, as
+// reported by DomainNormalizer, are ignored.
+func (p *Plot) ExtendRange(xmin, xmax, ymin, ymax float64) {
+	p.X.extendRange(xmin, xmax)
+	p.Y.extendRange(ymin, ymax)
+}
+
+// xyer is implemented by plotters that provide
+// their data as x, y pairs, such as those of the
+// plotter package that embed plotter.XYs.
+type xyer interface {
+	Len() int
+	XY(int) (x, y float64)
+}
+
+// PercentileRange sets the ranges of the X and Y axes to
+// span from the lo to the hi percentile, in [0, 100], of
+// the x and y values of the plot's plotters, so that the
+// axes are robust to outliers.  Percentiles between data
+// values are linearly interpolated.  Only plotters with
+// Len and XY methods, as provided by plotter.XYs, are
+// used, excluding those whose NoRange method returns true.
+// NaN and infinite values are ignored.  Data outside the
+// resulting ranges is not shown.
+//
+// PercentileRange returns an error, without modifying the
+// axes, if the percentiles are invalid or there are no
+// values.
+func (p *Plot) PercentileRange(lo, hi float64) error {
+	if !(0 <= lo && lo < hi && hi <= 100) {
+		return errors.New("plot: invalid percentile range")
+	}
+	var xs, ys []float64
+	for _, d := range p.plotters {
+		if n, ok := d.(NoRanger); ok && n.NoRange() {
+			continue
+		}
+		data, ok := d.(xyer)
+		if !ok {
+			continue
+		}
+		for i := 0; i < data.Len(); i++ {
+			x, y := data.XY(i)
+			if !math.IsNaN(x) && !math.IsInf(x, 0) {
+				xs = append(xs, x)
+			}
+			if !math.IsNaN(y) && !math.IsInf(y, 0) {
+				ys = append(ys, y)
+			}
+		}
+	}
+	if len(xs) == 0 || len(ys) == 0 {
+		return errors.New("plot: no data for percentile range")
+	}
+	sort.Float64s(xs)
+	sort.Float64s(ys)
+	p.X.Min, p.X.Max = percentile(xs, lo), percentile(xs, hi)
+	p.Y.Min, p.Y.Max = percentile(ys, lo), percentile(ys, hi)
+	return nil
+}
+
+// percentile returns the q percentile of the sorted
+// values, interpolating linearly between values.
+func percentile(sorted []float64, q float64) float64 {
+	pos := q / 100 * float64(len(sorted)-1)
+	i := int(pos)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	frac := pos - float64(i)
+	return sorted[i] + frac*(sorted[i+1]-sorted[i])
+}
+
+// Merge adds the plotters and legend entries of q to the
+// plot, after its own, and extends the ranges of the plot's
+// axes to include those of q's axes.  q is not modified.
+//
+// Merge returns an error, without modifying the plot, if
+// one of the plots has a nominal axis, as set by NominalX or
+// NominalY, where the other has a numeric axis, since the
+// positions on the two axes would not correspond.
+func (p *Plot) Merge(q *Plot) error {
+	if isNominal(p.X) != isNominal(q.X) {
+		return errors.New("plot: cannot merge nominal and numeric X axes")
+	}
+	if isNominal(p.Y) != isNominal(q.Y) {
+		return errors.New("plot: cannot merge nominal and numeric Y axes")
+	}
+	p.plotters = append(p.plotters, q.plotters...)
+	p.Legend.entries = append(p.Legend.entries, q.Legend.entries...)
+	p.ExtendRange(q.X.Min, q.X.Max, q.Y.Min, q.Y.Max)
+	return nil
+}
+
+// isNominal returns whether the axis is a nominal axis,
+// that is, it is marked with a non-empty set of constant
+// ticks.
+func isNominal(a Axis) bool {
+	ticks, ok := a.Tick.Marker.(ConstantTicks)
+	return ok && len(ticks) > 0
+}
+
+// AutoLegend adds a legend entry for each of the plot's
+// Plotters that implement the LegendEntryer interface,
+// in the order in which they were added to the plot.
+// Plotters that do not implement LegendEntryer or that
+// return an empty legend name are skipped.
+func (p *Plot) AutoLegend() {
+	for _, d := range p.plotters {
+		e, ok := d.(LegendEntryer)
+		if !ok {
+			continue
+		}
+		if name := e.LegendName(); name != "" {
+			p.Legend.Add(name, e)
+		}
+	}
+}
+
 // Draw draws a plot to a draw.Canvas.
 //
 // Plotters are drawn in the order in which they were
@@ -139,48 +432,509 @@ func (p *Plot) Add(ps ...Plotter) {
 // taken into account when padding the plot so that
 // none of their glyphs are clipped.
 func (p *Plot) Draw(c draw.Canvas) {
-	if p.BackgroundColor != nil {
-		c.SetColor(p.BackgroundColor)
-		c.Fill(c.Rectangle.Path())
+	p.draw(c, true)
+}
+
+// DrawDecorations draws everything except the plotters
+// to a draw.Canvas: the background, title, axes and
+// legend.  Together with DrawPlotters it allows the
+// decorations and the data to be drawn to separate
+// canvases, for example so that only the data need be
+// redrawn when it changes.
+func (p *Plot) DrawDecorations(c draw.Canvas) {
+	p.draw(c, false)
+}
+
+// DrawPlotters draws only the plotters of the plot into
+// the data area of a draw.Canvas, laid out as it would
+// be by Draw or DrawDecorations for a canvas of the
+// same size.
+func (p *Plot) DrawPlotters(c draw.Canvas) {
+	p.drawPlotters(p.DataCanvas(c))
+}
+
+// drawPlotters draws the plotters to the data area
+// canvas, clipped to the path returned by DataClip.
+func (p *Plot) drawPlotters(dataC draw.Canvas) {
+	if p.DataClip != nil {
+		dataC.Push()
+		defer dataC.Pop()
+		dataC.Clip(p.DataClip(dataC))
 	}
+	for _, data := range p.plotters {
+		data.Plot(dataC, p)
+	}
+}
+
+// draw draws the plot to a draw.Canvas, drawing the
+// plotters only if plotters is true.
+func (p *Plot) draw(c draw.Canvas, plotters bool) {
+	bg := c
+	c = draw.Crop(c, p.Margin, -p.Margin, p.Margin, -p.Margin)
+	titleC := c
 	if p.Title.Text != "" {
-		c.FillText(p.Title.TextStyle, vg.Point{X: c.Center().X, Y: c.Max.Y}, p.Title.Text)
-		c.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
-		c.Max.Y -= p.Title.Padding
+		c.Max.Y -= p.titleHeight()
 	}
+	c, outside := p.outsideLegends(c)
 
 	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
 	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
+	if p.EqualScale {
+		defer p.equalizeScale(c)()
+	}
+	x, y, ywidth, xheight := p.layoutAxes(c)
+	legendC := draw.Crop(c, ywidth, 0, xheight, 0)
+	left, right, bottom, top := p.legendInsets(legendC)
+	dataC := padY(p, y, padX(p, x, draw.Crop(legendC, left, -right, bottom, -top)))
 
-	ywidth := y.size()
+	p.drawBackground(bg, dataC)
+	if p.Title.Text != "" {
+		p.drawTitle(titleC)
+	}
+	x.draw(padX(p, x, draw.Crop(c, ywidth+left, -right, 0, 0)))
+	y.draw(padY(p, y, draw.Crop(c, 0, 0, xheight+bottom, -top)))
 
-	xheight := x.size()
-	x.draw(padX(p, draw.Crop(c, ywidth, 0, 0, 0)))
-	y.draw(padY(p, draw.Crop(c, 0, 0, xheight, 0)))
+	if x.Tick.Mirror {
+		x.drawMirror(dataC)
+	}
+	if y.Tick.Mirror {
+		y.drawMirror(dataC)
+	}
 
-	dataC := padY(p, padX(p, draw.Crop(c, ywidth, 0, xheight, 0)))
-	for _, data := range p.plotters {
-		data.Plot(dataC, p)
+	if plotters {
+		p.drawPlotters(dataC)
 	}
+	p.drawFrame(dataC)
 
-	p.Legend.Draw(draw.Crop(c, ywidth, 0, xheight, 0))
+	p.drawLegends(legendC, outside)
+}
+
+// drawBackground fills the canvas with BackgroundColor,
+// or, if DataBackgroundColor is not nil, fills the canvas
+// outside of the data area with BackgroundColor and the
+// data area with DataBackgroundColor.
+func (p *Plot) drawBackground(c, dataC draw.Canvas) {
+	if p.DataBackgroundColor == nil {
+		if p.BackgroundColor != nil {
+			c.SetColor(p.BackgroundColor)
+			c.Fill(c.Rectangle.Path())
+		}
+		return
+	}
+	if p.BackgroundColor != nil {
+		// The data area is cut out of the canvas
+		// by tracing it in the opposite direction.
+		r := dataC.Rectangle
+		path := c.Rectangle.Path()
+		path.Move(r.Min)
+		path.Line(vg.Point{X: r.Min.X, Y: r.Max.Y})
+		path.Line(r.Max)
+		path.Line(vg.Point{X: r.Max.X, Y: r.Min.Y})
+		path.Close()
+		c.SetColor(p.BackgroundColor)
+		c.Fill(path)
+	}
+	dataC.SetColor(p.DataBackgroundColor)
+	dataC.Fill(dataC.Rectangle.Path())
+}
+
+// canvasBackground returns the color that new image
+// canvases are filled with before the plot is drawn.
+// When the data area has its own background the canvas
+// is left transparent, so that the data area is not
+// covered by BackgroundColor.
+func (p *Plot) canvasBackground() color.Color {
+	if p.DataBackgroundColor != nil {
+		return nil
+	}
+	return p.BackgroundColor
+}
+
+// drawFrame draws the sides of the frame around
+// the data area canvas.  The horizontal sides are
+// extended to cover the corners of the vertical
+// sides.
+func (p *Plot) drawFrame(c draw.Canvas) {
+	f := p.Frame
+	minX := c.Min.X - f.Left.Width/2
+	maxX := c.Max.X + f.Right.Width/2
+	if f.Top.Width > 0 {
+		c.StrokeLine2(f.Top, minX, c.Max.Y, maxX, c.Max.Y)
+	}
+	if f.Bottom.Width > 0 {
+		c.StrokeLine2(f.Bottom, minX, c.Min.Y, maxX, c.Min.Y)
+	}
+	if f.Left.Width > 0 {
+		c.StrokeLine2(f.Left, c.Min.X, c.Min.Y, c.Min.X, c.Max.Y)
+	}
+	if f.Right.Width > 0 {
+		c.StrokeLine2(f.Right, c.Max.X, c.Min.Y, c.Max.X, c.Max.Y)
+	}
+}
+
+// titleBoxed returns whether the title is
+// drawn in a box.
+func (p *Plot) titleBoxed() bool {
+	return p.Title.BackgroundColor != nil || p.Title.Border.Width > 0
+}
+
+// titleBox returns the box drawn around the title,
+// relative to the top center of the title area.
+func (p *Plot) titleBox() vg.Rectangle {
+	pad := p.Title.BoxPadding
+	w := p.Title.Width(p.Title.Text)
+	h := p.Title.Height(p.Title.Text) + p.Title.Font.Extents().Descent
+	return vg.Rectangle{
+		Min: vg.Point{X: -w/2 - pad, Y: -h - 2*pad},
+		Max: vg.Point{X: w/2 + pad, Y: 0},
+	}
+}
+
+// titleHeight returns the height reserved at
+// the top of the plot for the title.
+func (p *Plot) titleHeight() vg.Length {
+	if p.Title.Text == "" {
+		return 0
+	}
+	if p.titleBoxed() {
+		return p.titleBox().Size().Y + p.Title.Padding
+	}
+	return p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent + p.Title.Padding
+}
+
+// drawTitle draws the title, and its box if it has
+// one, at the top center of the given draw.Canvas.
+func (p *Plot) drawTitle(c draw.Canvas) {
+	pt := vg.Point{X: c.Center().X, Y: c.Max.Y}
+	if !p.titleBoxed() {
+		c.FillText(p.Title.TextStyle, pt, p.Title.Text)
+		return
+	}
+
+	box := p.titleBox()
+	box.Min = box.Min.Add(pt)
+	box.Max = box.Max.Add(pt)
+	if p.Title.BackgroundColor != nil {
+		c.SetColor(p.Title.BackgroundColor)
+		c.Fill(box.Path())
+	}
+	if p.Title.Border.Width > 0 {
+		c.SetLineStyle(p.Title.Border)
+		c.Stroke(box.Path())
+	}
+
+	// The text is positioned at the top of the box,
+	// inside the padding, whatever its alignment.
+	sty := p.Title.TextStyle
+	sty.XAlign = draw.XCenter
+	sty.YAlign = draw.YTop
+	c.FillText(sty, vg.Point{X: pt.X, Y: pt.Y - p.Title.BoxPadding}, p.Title.Text)
+}
+
+// drawLegends draws Legend and Legends to the given
+// draw.Canvas, stacking the legends placed in the
+// same corner, and draws the legends with Outside set
+// to their canvases given by outside.
+func (p *Plot) drawLegends(c draw.Canvas, outside map[*Legend]draw.Canvas) {
+	placed := make(map[*Legend]draw.Canvas)
+	p.placeLegends(c, func(l *Legend, lc draw.Canvas) {
+		placed[l] = lc
+	})
+	for _, l := range p.legends() {
+		if lc, ok := placed[l]; ok {
+			l.Draw(lc)
+		} else if lc, ok := outside[l]; ok {
+			l.Draw(lc)
+		}
+	}
+}
+
+// legends returns Legend and Legends, in order.
+func (p *Plot) legends() []*Legend {
+	legends := append([]*Legend{&p.Legend}, make([]*Legend, len(p.Legends))...)
+	for i := range p.Legends {
+		legends[i+1] = &p.Legends[i]
+	}
+	return legends
+}
+
+// placeLegends calls place with each of Legend and
+// Legends that has entries and is not drawn Outside,
+// in order, and the canvas that the legend is drawn
+// to, stacking the legends placed in the same corner.
+func (p *Plot) placeLegends(c draw.Canvas, place func(l *Legend, lc draw.Canvas)) {
+	type corner struct{ top, left bool }
+	offsets := make(map[corner]vg.Length)
+	for _, l := range p.legends() {
+		if len(l.entries) == 0 || l.Outside {
+			continue
+		}
+		k := corner{top: l.Top, left: l.Left}
+		lc := c
+		if l.Top {
+			lc.Max.Y -= offsets[k]
+		} else {
+			lc.Min.Y += offsets[k]
+		}
+		place(l, lc)
+		offsets[k] += l.Rectangle(lc).Size().Y + l.entryHeight()/2 + l.Padding
+	}
+}
+
+// outsideLegends returns the part of the given canvas
+// that remains for the axes and the data area after
+// space is reserved along its edges for the legends with
+// Outside set, along with the canvases that those legends
+// are drawn to.  Legends placed along the same edge are
+// stacked inward from the edge, in order.
+func (p *Plot) outsideLegends(c draw.Canvas) (draw.Canvas, map[*Legend]draw.Canvas) {
+	var outside map[*Legend]draw.Canvas
+	for _, l := range p.legends() {
+		if len(l.entries) == 0 || !l.Outside {
+			continue
+		}
+		size := l.Rectangle(draw.Canvas{}).Size()
+		gap := l.entryHeight() / 2
+		lc := c
+		switch {
+		case l.Horizontal && l.Top:
+			lc.Min.Y = c.Max.Y - size.Y
+			c.Max.Y = lc.Min.Y - gap
+		case l.Horizontal:
+			lc.Max.Y = c.Min.Y + size.Y
+			c.Min.Y = lc.Max.Y + gap
+		case l.Left:
+			lc.Max.X = c.Min.X + size.X
+			c.Min.X = lc.Max.X + gap
+		default:
+			lc.Min.X = c.Max.X - size.X
+			c.Max.X = lc.Min.X - gap
+		}
+		if outside == nil {
+			outside = make(map[*Legend]draw.Canvas)
+		}
+		outside[l] = lc
+	}
+	return c, outside
+}
+
+// legendInsets returns the lengths by which each side
+// of the data area is inset to make room for the legends
+// with Inset set, when the legends are placed in the
+// given canvas.
+func (p *Plot) legendInsets(c draw.Canvas) (left, right, bottom, top vg.Length) {
+	p.placeLegends(c, func(l *Legend, lc draw.Canvas) {
+		if !l.Inset {
+			return
+		}
+		size := l.Rectangle(lc).Size()
+		switch {
+		case l.Horizontal && l.Top:
+			top = maxLength(top, c.Max.Y-lc.Max.Y+size.Y-l.YOffs)
+		case l.Horizontal:
+			bottom = maxLength(bottom, lc.Min.Y-c.Min.Y+size.Y+l.YOffs)
+		case l.Left:
+			left = maxLength(left, size.X+l.XOffs)
+		default:
+			right = maxLength(right, size.X-l.XOffs)
+		}
+	})
+	return left, right, bottom, top
 }
 
 // DataCanvas returns a new draw.Canvas that
 // is the subset of the given draw area into which
 // the plot data will be drawn.
 func (p *Plot) DataCanvas(da draw.Canvas) draw.Canvas {
-	if p.Title.Text != "" {
-		da.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
-		da.Max.Y -= p.Title.Padding
+	da = draw.Crop(da, p.Margin, -p.Margin, p.Margin, -p.Margin)
+	da.Max.Y -= p.titleHeight()
+	da, _ = p.outsideLegends(da)
+	p.X.sanitizeRange()
+	p.Y.sanitizeRange()
+	if p.EqualScale {
+		defer p.equalizeScale(da)()
+	}
+	return p.dataArea(da)
+}
+
+// MinSize returns the smallest width and height of a
+// canvas that the plot can be drawn to without its
+// title, axis labels, tick labels or legends being
+// clipped or overlapping.  The data area is made large
+// enough to hold the legends and the axis labels, and
+// to place the major tick labels of each axis side by
+// side.  Relative tick lengths and label paddings are
+// treated as zero.
+//
+// An error is returned if the range of an axis is NaN.
+func (p *Plot) MinSize() (w, h vg.Length, err error) {
+	if math.IsNaN(p.X.Min) || math.IsNaN(p.X.Max) || math.IsNaN(p.Y.Min) || math.IsNaN(p.Y.Max) {
+		return 0, 0, errors.New("plot: axis range is NaN")
 	}
 	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
 	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
-	return padY(p, padX(p, draw.Crop(da, y.size(), 0, x.size(), 0)))
+	x := horizontalAxis{p.X.resolve(0)}
+	y := verticalAxis{p.Y.resolve(0)}
+
+	var dataW, dataH vg.Length
+	if x.Label.Text != "" {
+		dataW = x.Label.Width(x.Label.Text)
+	}
+	if y.Label.Text != "" {
+		dataH = y.Label.Width(y.Label.Text)
+	}
+	var ticksW, ticksH vg.Length
+	for _, t := range x.Tick.Marker.Ticks(x.Min, x.Max) {
+		if !t.IsMinor() {
+			ticksW += x.Tick.Label.Width(t.Label + " ")
+		}
+	}
+	for _, t := range y.Tick.Marker.Ticks(y.Min, y.Max) {
+		if !t.IsMinor() {
+			ticksH += y.Tick.Label.Height(t.Label)
+		}
+	}
+	if ticksW > dataW {
+		dataW = ticksW
+	}
+	if ticksH > dataH {
+		dataH = ticksH
+	}
+
+	legendH := make(map[bool]vg.Length)
+	var outW, outH, outMinW vg.Length
+	for _, l := range append([]Legend{p.Legend}, p.Legends...) {
+		if len(l.entries) == 0 {
+			continue
+		}
+		r := l.Rectangle(draw.Canvas{}).Size()
+		if l.Outside {
+			gap := l.entryHeight() / 2
+			if l.Horizontal {
+				outH += r.Y + gap
+				outMinW = maxLength(outMinW, r.X)
+			} else {
+				outW += r.X + gap
+				dataH = maxLength(dataH, r.Y)
+			}
+			continue
+		}
+		if r.X > dataW {
+			dataW = r.X
+		}
+		if legendH[l.Top] != 0 {
+			legendH[l.Top] += l.entryHeight()/2 + l.Padding
+		}
+		legendH[l.Top] += r.Y
+	}
+	if legendH[true]+legendH[false] > dataH {
+		dataH = legendH[true] + legendH[false]
+	}
+
+	w = maxLength(y.size()+dataW+outW, outMinW)
+	h = x.size() + dataH + outH
+	if p.Title.Text != "" {
+		tw := p.Title.Width(p.Title.Text)
+		if p.titleBoxed() {
+			tw = p.titleBox().Size().X
+		}
+		if tw > w {
+			w = tw
+		}
+		h += p.titleHeight()
+	}
+	return w + 2*p.Margin, h + 2*p.Margin, nil
+}
+
+// canvasSizeTolerance is the largest difference between
+// the requested and computed data area dimensions at which
+// CanvasSize stops refining the canvas size.
+const canvasSizeTolerance = 1e-6
+
+// CanvasSize returns the width and height of a canvas for
+// which the data area of the plot, as returned by
+// DataCanvas, has the given size.
+//
+// The space taken by the axes and the padding for glyphs
+// depend on the size of the canvas, so the size is found
+// by successive refinement.
+func (p *Plot) CanvasSize(data vg.Point) (w, h vg.Length) {
+	w, h = data.X, data.Y
+	for i := 0; i < 20; i++ {
+		c := draw.Canvas{Rectangle: vg.Rectangle{Max: vg.Point{X: w, Y: h}}}
+		got := p.DataCanvas(c).Size()
+		dw, dh := data.X-got.X, data.Y-got.Y
+		if math.Abs(float64(dw)) < canvasSizeTolerance && math.Abs(float64(dh)) < canvasSizeTolerance {
+			break
+		}
+		w += dw
+		h += dh
+	}
+	return w, h
+}
+
+// dataArea returns the subset of the given draw area,
+// excluding the title, into which the plot data will
+// be drawn.
+func (p *Plot) dataArea(c draw.Canvas) draw.Canvas {
+	x, y, ywidth, xheight := p.layoutAxes(c)
+	c = draw.Crop(c, ywidth, 0, xheight, 0)
+	left, right, bottom, top := p.legendInsets(c)
+	return padY(p, y, padX(p, x, draw.Crop(c, left, -right, bottom, -top)))
+}
+
+// equalizeScale expands the range of one of the axes
+// so that a data unit has the same length along both
+// axes when drawn to the given draw area, excluding
+// the title.  It returns a function that restores the
+// original ranges of the axes, so that the expanded
+// range is used only while the plot is drawn.
+func (p *Plot) equalizeScale(c draw.Canvas) (restore func()) {
+	xmin, xmax, ymin, ymax := p.X.Min, p.X.Max, p.Y.Min, p.Y.Max
+	restore = func() {
+		p.X.Min, p.X.Max, p.Y.Min, p.Y.Max = xmin, xmax, ymin, ymax
+	}
+
+	// The axis sizes depend on the tick labels, which
+	// depend on the axis ranges, so the original ranges
+	// are expanded again for the resulting data area
+	// until its size no longer changes.
+	var last vg.Point
+	for i := 0; i < 5; i++ {
+		size := p.dataArea(c).Size()
+		if size.X <= 0 || size.Y <= 0 || size == last {
+			return restore
+		}
+		last = size
+		restore()
+		xPer := (p.X.Max - p.X.Min) / float64(size.X)
+		yPer := (p.Y.Max - p.Y.Min) / float64(size.Y)
+		switch {
+		case xPer < yPer:
+			expandRange(&p.X, yPer*float64(size.X))
+		case yPer < xPer:
+			expandRange(&p.Y, xPer*float64(size.Y))
+		}
+	}
+	return restore
+}
+
+// expandRange expands the range of the axis to the
+// given width, centered on the current range.
+func expandRange(a *Axis, width float64) {
+	mid := (a.Min + a.Max) / 2
+	a.Min = mid - width/2
+	a.Max = mid + width/2
+}
+
+// minDimension returns the smaller of the width and
+// height of the given canvas.
+func minDimension(c draw.Canvas) vg.Length {
+	sz := c.Size()
+	if sz.X < sz.Y {
+		return sz.X
+	}
+	return sz.Y
 }
 
 // DrawGlyphBoxes draws red outlines around the plot's
@@ -196,10 +950,9 @@ func (p *Plot) DrawGlyphBoxes(c *draw.Canvas) {
 
 // padX returns a draw.Canvas that is padded horizontally
 // so that glyphs will no be clipped.
-func padX(p *Plot, c draw.Canvas) draw.Canvas {
-	glyphs := p.GlyphBoxes(p)
+func padX(p *Plot, xAxis horizontalAxis, c draw.Canvas) draw.Canvas {
+	glyphs := padGlyphs(p.GlyphBoxes(p), p.GlyphPadding)
 	l := leftMost(&c, glyphs)
-	xAxis := horizontalAxis{p.X}
 	glyphs = append(glyphs, xAxis.GlyphBoxes(p)...)
 	r := rightMost(&c, glyphs)
 
@@ -218,6 +971,27 @@ func padX(p *Plot, c draw.Canvas) draw.Canvas {
 	}
 }
 
+// padGlyphs grows each of the boxes by pad on each
+// side.  A box is only grown in the directions in
+// which it has an extent, so that boxes that are
+// ignored when padding remain ignored.
+func padGlyphs(boxes []GlyphBox, pad vg.Length) []GlyphBox {
+	if pad == 0 {
+		return boxes
+	}
+	for i, b := range boxes {
+		if b.Size().X > 0 {
+			boxes[i].Min.X -= pad
+			boxes[i].Max.X += pad
+		}
+		if b.Size().Y > 0 {
+			boxes[i].Min.Y -= pad
+			boxes[i].Max.Y += pad
+		}
+	}
+	return boxes
+}
+
 // rightMost returns the right-most GlyphBox.
 func rightMost(c *draw.Canvas, boxes []GlyphBox) GlyphBox {
 	maxx := c.Max.X
@@ -252,10 +1026,9 @@ func leftMost(c *draw.Canvas, boxes []GlyphBox) GlyphBox {
 
 // padY returns a draw.Canvas that is padded vertically
 // so that glyphs will no be clipped.
-func padY(p *Plot, c draw.Canvas) draw.Canvas {
-	glyphs := p.GlyphBoxes(p)
+func padY(p *Plot, yAxis verticalAxis, c draw.Canvas) draw.Canvas {
+	glyphs := padGlyphs(p.GlyphBoxes(p), p.GlyphPadding)
 	b := bottomMost(&c, glyphs)
-	yAxis := verticalAxis{p.Y}
 	glyphs = append(glyphs, yAxis.GlyphBoxes(p)...)
 	t := topMost(&c, glyphs)
 
@@ -385,19 +1158,49 @@ func (p *Plot) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 // e.g., the x value 0 is centered above the first name and
 // 1 is above the second name, etc.  Labels for x values
 // that do not end up in range of the X axis will not have
-// tick marks.
+// tick marks.  The Y axis is padded by half the width of
+// the widest name so that no name overlaps it.
 func (p *Plot) NominalX(names ...string) {
 	p.X.Tick.Width = 0
 	p.X.Tick.Length = 0
 	p.X.Width = 0
-	p.Y.Padding = p.X.Tick.Label.Width(names[0]) / 2
+	p.Y.Padding = 0
 	ticks := make([]Tick, len(names))
 	for i, name := range names {
 		ticks[i] = Tick{float64(i), name}
+		if w := p.X.Tick.Label.Width(name) / 2; w > p.Y.Padding {
+			p.Y.Padding = w
+		}
 	}
 	p.X.Tick.Marker = ConstantTicks(ticks)
 }
 
+// NominalXGroups configures the plot to have a nominal X
+// axis, as NominalX does, with the names of each group
+// labeled by the name of the group on a second tier below
+// them.  The names within groups[i] are given by names[i],
+// and the names of all of the groups are placed along the
+// axis in order.  An error is returned, and the plot is
+// left unchanged, if the numbers of groups and of slices
+// of names differ.
+func (p *Plot) NominalXGroups(groups []string, names [][]string) error {
+	if len(groups) != len(names) {
+		return fmt.Errorf("plot: number of groups (%d) does not match number of name slices (%d)", len(groups), len(names))
+	}
+	var all []string
+	p.X.Tick.Groups = make([]TickGroup, len(groups))
+	for i, g := range groups {
+		p.X.Tick.Groups[i] = TickGroup{
+			Min:   float64(len(all)),
+			Max:   float64(len(all) + len(names[i]) - 1),
+			Label: g,
+		}
+		all = append(all, names[i]...)
+	}
+	p.NominalX(all...)
+	return nil
+}
+
 // HideX configures the X axis so that it will not be drawn.
 func (p *Plot) HideX() {
 	p.X.Tick.Length = 0
@@ -418,15 +1221,19 @@ func (p *Plot) HideAxes() {
 	p.HideY()
 }
 
-// NominalY is like NominalX, but for the Y axis.
+// NominalY is like NominalX, but for the Y axis.  The X
+// axis is padded by half the height of the tallest name.
 func (p *Plot) NominalY(names ...string) {
 	p.Y.Tick.Width = 0
 	p.Y.Tick.Length = 0
 	p.Y.Width = 0
-	p.X.Padding = p.Y.Tick.Label.Height(names[0]) / 2
+	p.X.Padding = 0
 	ticks := make([]Tick, len(names))
 	for i, name := range names {
 		ticks[i] = Tick{float64(i), name}
+		if h := p.Y.Tick.Label.Height(name) / 2; h > p.X.Padding {
+			p.X.Padding = h
+		}
 	}
 	p.Y.Tick.Marker = ConstantTicks(ticks)
 }
@@ -436,22 +1243,149 @@ func (p *Plot) NominalY(names ...string) {
 //
 // Supported formats are:
 //
-//  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
+//  eps, jpg|jpeg, pdf, png, ps, svg, and tif|tiff,
+//
+// and any formats registered with draw.RegisterFormat.
+//
+// If DataSize is set, w and h are ignored and the canvas
+// size is computed from DataSize.  The plot's Metadata is
+// recorded by the pdf and svg formats.
 func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
-	c, err := draw.NewFormattedCanvas(w, h, format)
+	if p.DataSize.X != 0 && p.DataSize.Y != 0 {
+		w, h = p.CanvasSize(p.DataSize)
+	}
+	c, err := draw.NewFormattedCanvasBackground(w, h, format, p.canvasBackground())
 	if err != nil {
 		return nil, err
 	}
+	if m, ok := c.(vg.MetadataSetter); ok {
+		m.SetMetadata(p.Metadata)
+	}
 	p.Draw(draw.New(c))
 	return c, nil
 }
 
+// DrawTiles draws the plot to a grid of images with the
+// given number of rows and columns, for output too large
+// to be held in a single image.  The plot is laid out as
+// it would be on a single w×h canvas at the given dots per
+// inch, and tiles[i][j] holds the part of it in row i,
+// counted from the top, and column j, counted from the
+// left.  The tile edges fall on pixel boundaries, so the
+// tiles can be placed side by side to form the whole plot
+// without seams.  If DataSize is set, w and h are ignored
+// and the canvas size is computed from DataSize.
+func (p *Plot) DrawTiles(w, h vg.Length, rows, cols, dpi int) (tiles [][]image.Image, err error) {
+	if rows <= 0 || cols <= 0 {
+		return nil, errors.New("plot: tile grid must have positive rows and columns")
+	}
+	if dpi <= 0 {
+		return nil, errors.New("plot: dpi must be positive")
+	}
+	if p.DataSize.X != 0 && p.DataSize.Y != 0 {
+		w, h = p.CanvasSize(p.DataSize)
+	}
+	width := int(w/vg.Inch*vg.Length(dpi) + 0.5)
+	height := int(h/vg.Inch*vg.Length(dpi) + 0.5)
+	if width < cols || height < rows {
+		return nil, errors.New("plot: tiles must be at least one pixel in size")
+	}
+
+	// toLength returns the length of n pixels.
+	toLength := func(n int) vg.Length {
+		return vg.Length(n) / vg.Length(dpi) * vg.Inch
+	}
+
+	tiles = make([][]image.Image, rows)
+	for i := range tiles {
+		tiles[i] = make([]image.Image, cols)
+		// Rows are counted from the top, but the
+		// canvas y axis points upwards.
+		top, bottom := i*height/rows, (i+1)*height/rows
+		y0 := toLength(height - bottom - tilePad)
+		for j := range tiles[i] {
+			left, right := j*width/cols, (j+1)*width/cols
+			x0 := toLength(left - tilePad)
+
+			// The tile is drawn with a border of padding
+			// that is then removed, since the rasterizer
+			// accumulates the coverage of any drawing
+			// beyond the left edge of an image into its
+			// first column, and similarly for the top.
+			dx, dy := right-left, bottom-top
+			img := image.NewRGBA(image.Rect(0, 0, dx+2*tilePad, dy+2*tilePad))
+			c := vgimg.NewWith(
+				vgimg.UseImage(img),
+				vgimg.UseDPI(dpi),
+				vgimg.UseBackgroundColor(p.canvasBackground()),
+			)
+			p.Draw(draw.Canvas{
+				Canvas: c,
+				Rectangle: vg.Rectangle{
+					Min: vg.Point{X: -x0, Y: -y0},
+					Max: vg.Point{X: w - x0, Y: h - y0},
+				},
+			})
+			tile := image.NewRGBA(image.Rect(0, 0, dx, dy))
+			for y := 0; y < dy; y++ {
+				off := img.PixOffset(tilePad, tilePad+y)
+				copy(tile.Pix[y*tile.Stride:(y+1)*tile.Stride], img.Pix[off:])
+			}
+			tiles[i][j] = tile
+		}
+	}
+	return tiles, nil
+}
+
+// DrawImage draws the plot into img, replacing its
+// contents, at the given dots per inch.  The plot is
+// sized to fill the bounds of img, which may be a sub-image
+// of a larger image, and DataSize is ignored.  The image is
+// first filled with BackgroundColor, or cleared to
+// transparent if BackgroundColor is nil.  Drawing
+// repeatedly into the same image avoids allocating a
+// new image for each frame of an animated plot.
+func (p *Plot) DrawImage(img *image.RGBA, dpi int) error {
+	if dpi <= 0 {
+		return errors.New("plot: dpi must be positive")
+	}
+	r := img.Bounds()
+	if r.Empty() {
+		return errors.New("plot: image must not be empty")
+	}
+	if r.Min != (image.Point{}) {
+		// The canvas is drawn from the origin, so view
+		// the pixels of img as an image based at 0,0.
+		img = &image.RGBA{
+			Pix:    img.Pix[img.PixOffset(r.Min.X, r.Min.Y):],
+			Stride: img.Stride,
+			Rect:   image.Rectangle{Max: r.Size()},
+		}
+	}
+	c := vgimg.NewWith(
+		vgimg.UseImage(img),
+		vgimg.UseDPI(dpi),
+		vgimg.UseBackgroundColor(p.canvasBackground()),
+	)
+	p.Draw(draw.New(c))
+	return nil
+}
+
+// tilePad is the number of pixels of padding around
+// each tile drawn by DrawTiles.
+const tilePad = 1
+
 // Save saves the plot to an image file.  The file format is determined
 // by the extension.
 //
 // Supported extensions are:
 //
-//  .eps, .jpg, .jpeg, .pdf, .png, .svg, .tif and .tiff.
+//  .eps, .jpg, .jpeg, .pdf, .png, .ps, .svg, .tif and .tiff,
+//
+// and those of any formats registered with draw.RegisterFormat.
+//
+// If DataSize is set, w and h are ignored and the canvas
+// size is computed from DataSize.
 func (p *Plot) Save(w, h vg.Length, file string) (err error) {
 	f, err := os.Create(file)
 	if err != nil {
@@ -476,3 +1410,34 @@ func (p *Plot) Save(w, h vg.Length, file string) (err error) {
 	_, err = c.WriteTo(f)
 	return err
 }
+
+// DataURI returns the plot as a data URI of the specified
+// image format, with the image encoded as base64, for use
+// as the src of an HTML img element.  The supported formats
+// are those of WriterTo, including any formats registered
+// with draw.RegisterFormat, whose MIME type is given in the
+// data URI.
+func (p *Plot) DataURI(w, h vg.Length, format string) (string, error) {
+	format = strings.ToLower(format)
+	mime, ok := draw.MIMEType(format)
+	if !ok {
+		return "", fmt.Errorf("plot: unsupported format: %q", format)
+	}
+	c, err := p.WriterTo(w, h, format)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("data:" + mime + ";base64,")
+	enc := base64.NewEncoder(base64.StdEncoding, &buf)
+	_, err = c.WriteTo(enc)
+	if err != nil {
+		return "", err
+	}
+	err = enc.Close()
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
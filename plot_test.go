@@ -6,9 +6,17 @@ package plot_test
 
 import (
 	"bytes"
+	"compress/zlib"
+	"encoding/base64"
 	"fmt"
+	"image"
 	"image/color"
+	"image/png"
+	"io/ioutil"
+	"math"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"gonum.org/v1/plot"
@@ -16,6 +24,8 @@ import (
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 	"gonum.org/v1/plot/vg/recorder"
+	"gonum.org/v1/plot/vg/vgimg"
+	"gonum.org/v1/plot/vg/vgsvg"
 )
 
 func TestLegendAlignment(t *testing.T) {
@@ -229,3 +239,1446 @@ func printFirstDiff(got, want []recorder.Action) string {
 	}
 	return buf.String()
 }
+
+func TestHideTickLabels(t *testing.T) {
+	draws := func(hide bool) (vg.Rectangle, []string) {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 10
+		p.Y.Min, p.Y.Max = 0, 10
+		p.Y.Tick.HideLabels = hide
+
+		var r recorder.Canvas
+		c := draw.NewCanvas(&r, 100, 100)
+		p.Draw(c)
+
+		var text []string
+		for _, a := range r.Actions {
+			if fs, ok := a.(*recorder.FillString); ok {
+				text = append(text, fs.String)
+			}
+		}
+		return p.DataCanvas(c).Rectangle, text
+	}
+
+	shownArea, shown := draws(false)
+	hiddenArea, hidden := draws(true)
+	if shownArea != hiddenArea {
+		t.Errorf("data area changed when hiding labels: got:%v want:%v", hiddenArea, shownArea)
+	}
+	if len(hidden) >= len(shown) {
+		t.Errorf("unexpected number of labels drawn when hiding Y tick labels: got:%d, shown:%d", len(hidden), len(shown))
+	}
+}
+
+func TestFrame(t *testing.T) {
+	frame := func(set bool) (draw.Canvas, []vg.Length) {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.HideAxes()
+		p.X.Min, p.X.Max = 0, 10
+		p.Y.Min, p.Y.Max = 0, 10
+		if set {
+			p.Frame.Left = draw.LineStyle{Color: color.Black, Width: 3}
+			p.Frame.Bottom = draw.LineStyle{Color: color.Black, Width: 5}
+		}
+
+		var r recorder.Canvas
+		c := draw.NewCanvas(&r, 100, 100)
+		p.Draw(c)
+
+		var widths []vg.Length
+		var width vg.Length
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.SetLineWidth:
+				width = a.Width
+			case *recorder.Stroke:
+				if width > 0 {
+					widths = append(widths, width)
+				}
+			}
+		}
+		return p.DataCanvas(c), widths
+	}
+
+	plain, none := frame(false)
+	framed, widths := frame(true)
+	if plain.Rectangle != framed.Rectangle {
+		t.Errorf("data area changed by frame: got:%v want:%v", framed.Rectangle, plain.Rectangle)
+	}
+	if len(none) != 0 {
+		t.Errorf("unexpected strokes without frame: %v", none)
+	}
+	want := []vg.Length{5, 3}
+	if !reflect.DeepEqual(widths, want) {
+		t.Errorf("unexpected frame strokes: got:%v want:%v", widths, want)
+	}
+}
+
+func TestNominalXGroups(t *testing.T) {
+	draws := func(groups bool) (vg.Rectangle, map[string]vg.Point) {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Y.Min, p.Y.Max = 0, 10
+		if groups {
+			err = p.NominalXGroups([]string{"2017", "2018"}, [][]string{{"Jan", "Feb"}, {"Jan", "Feb", "Mar"}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		} else {
+			p.NominalX("Jan", "Feb", "Jan", "Feb", "Mar")
+		}
+		p.X.Min, p.X.Max = -0.5, 4.5
+
+		var r recorder.Canvas
+		c := draw.NewCanvas(&r, 200, 100)
+		p.Draw(c)
+
+		text := make(map[string]vg.Point)
+		for _, a := range r.Actions {
+			if fs, ok := a.(*recorder.FillString); ok {
+				text[fs.String] = fs.Point
+			}
+		}
+		return p.DataCanvas(c).Rectangle, text
+	}
+
+	plainArea, _ := draws(false)
+	groupedArea, text := draws(true)
+	if groupedArea.Min.Y <= plainArea.Min.Y {
+		t.Errorf("no space reserved for group labels: got data area bottom %v, want above %v",
+			groupedArea.Min.Y, plainArea.Min.Y)
+	}
+	for _, label := range []string{"2017", "2018", "Mar"} {
+		if _, ok := text[label]; !ok {
+			t.Fatalf("label %q not drawn", label)
+		}
+	}
+	if text["2017"].Y >= text["Mar"].Y {
+		t.Errorf("group label not below tick labels: got group y=%v tick y=%v", text["2017"].Y, text["Mar"].Y)
+	}
+	if text["2017"].X >= text["2018"].X {
+		t.Errorf("group labels out of order: got x=%v and x=%v", text["2017"].X, text["2018"].X)
+	}
+}
+
+func TestNominalXGroupsMismatch(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = p.NominalXGroups([]string{"2017", "2018"}, [][]string{{"Jan", "Feb"}})
+	if err == nil {
+		t.Error("expected error for more groups than name slices")
+	}
+	if p.X.Tick.Groups != nil {
+		t.Errorf("plot changed by failed call: got groups:%v", p.X.Tick.Groups)
+	}
+}
+
+func TestNominalPadding(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := []string{"1", "1,000", "1,000,000"}
+	p.NominalX(names...)
+	if want := p.X.Tick.Label.Width(names[2]) / 2; p.Y.Padding != want {
+		t.Errorf("unexpected y padding: got:%v want:%v", p.Y.Padding, want)
+	}
+
+	p.Y.Tick.Label.Font.Size = 8
+	p.NominalY("a", "b\nc")
+	if want := p.Y.Tick.Label.Height("b\nc") / 2; p.X.Padding != want {
+		t.Errorf("unexpected x padding: got:%v want:%v", p.X.Padding, want)
+	}
+
+	p.NominalX()
+	if p.Y.Padding != 0 {
+		t.Errorf("unexpected y padding without names: got:%v want:0", p.Y.Padding)
+	}
+}
+
+func TestExtendRange(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.ExtendRange(1, 2, 3, 4)
+	p.ExtendRange(0, 1.5, 3.5, 5)
+	if p.X.Min != 0 || p.X.Max != 2 || p.Y.Min != 3 || p.Y.Max != 5 {
+		t.Errorf("unexpected range: got x=[%v, %v] y=[%v, %v], want x=[0, 2] y=[3, 5]",
+			p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+}
+
+func TestAddLogScale(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Y.Scale = plot.LogScale{}
+
+	// The zero and negative values of the line are
+	// outside of the domain of the log scale.
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 0.5}, {X: 2, Y: 100}, {X: 3, Y: -1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+	if p.Y.Min != 0.5 || p.Y.Max != 100 {
+		t.Errorf("unexpected y range: got:[%v, %v] want:[0.5, 100]", p.Y.Min, p.Y.Max)
+	}
+	if p.X.Min != 0 || p.X.Max != 3 {
+		t.Errorf("unexpected x range: got:[%v, %v] want:[0, 3]", p.X.Min, p.X.Max)
+	}
+
+	// Ranges without positive values are ignored.
+	p.ExtendRange(-1, 5, -10, 0)
+	if p.Y.Min != 0.5 || p.Y.Max != 100 {
+		t.Errorf("unexpected y range after extending: got:[%v, %v] want:[0.5, 100]", p.Y.Min, p.Y.Max)
+	}
+	if p.X.Min != -1 || p.X.Max != 5 {
+		t.Errorf("unexpected x range after extending: got:[%v, %v] want:[-1, 5]", p.X.Min, p.X.Max)
+	}
+}
+
+func TestEqualScale(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Equal scale"
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 1
+	p.EqualScale = true
+
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 200, 100)
+	l := p.Layout(c)
+	if l.X.Min > 0 || l.X.Max < 10 || l.Y.Min > 0 || l.Y.Max < 1 {
+		t.Errorf("data range not contained in axes: got x=[%v, %v] y=[%v, %v]",
+			l.X.Min, l.X.Max, l.Y.Min, l.Y.Max)
+	}
+	if mid := (l.Y.Min + l.Y.Max) / 2; math.Abs(mid-0.5) > 1e-12 {
+		t.Errorf("expanded range is not centered: got mid=%v want 0.5", mid)
+	}
+
+	size := l.DataArea.Size()
+	xPer := (l.X.Max - l.X.Min) / float64(size.X)
+	yPer := (l.Y.Max - l.Y.Min) / float64(size.Y)
+	if math.Abs(xPer-yPer) > 1e-3*xPer {
+		t.Errorf("unequal axis scales: got x=%v y=%v units per length", xPer, yPer)
+	}
+
+	// Drawing does not change the ranges of the axes, so
+	// the equalized ranges depend only on the canvas drawn to.
+	p.Draw(c)
+	p.DataCanvas(draw.NewCanvas(new(recorder.Canvas), 100, 200))
+	if p.X.Min != 0 || p.X.Max != 10 || p.Y.Min != 0 || p.Y.Max != 1 {
+		t.Errorf("axis ranges changed by drawing: got x=[%v, %v] y=[%v, %v]",
+			p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+	if got := p.Layout(c); !reflect.DeepEqual(got, l) {
+		t.Errorf("layout changed after drawing to other canvases:\ngot: %+v\nwant:%+v", got, l)
+	}
+}
+
+func TestMargin(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+	p.HideAxes()
+
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 100, 100)
+	unpadded := p.DataCanvas(c).Rectangle
+
+	p.Margin = 10
+	padded := p.DataCanvas(c).Rectangle
+	want := vg.Rectangle{
+		Min: unpadded.Min.Add(vg.Point{X: 10, Y: 10}),
+		Max: unpadded.Max.Sub(vg.Point{X: 10, Y: 10}),
+	}
+	if padded != want {
+		t.Errorf("unexpected data area with margin: got:%v want:%v", padded, want)
+	}
+}
+
+func TestGlyphPadding(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := plotter.NewScatter(plotter.XYs{{X: 0, Y: 0}, {X: 10, Y: 10}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Radius = 2
+	p.Add(s)
+	p.HideAxes()
+
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 100, 100)
+	tight := p.DataCanvas(c).Rectangle
+
+	p.GlyphPadding = 3
+	padded := p.DataCanvas(c).Rectangle
+	want := vg.Rectangle{
+		Min: tight.Min.Add(vg.Point{X: 3, Y: 3}),
+		Max: tight.Max.Sub(vg.Point{X: 3, Y: 3}),
+	}
+	const tol = 1e-9
+	if math.Abs(float64(padded.Min.X-want.Min.X)) > tol || math.Abs(float64(padded.Min.Y-want.Min.Y)) > tol ||
+		math.Abs(float64(padded.Max.X-want.Max.X)) > tol || math.Abs(float64(padded.Max.Y-want.Max.Y)) > tol {
+		t.Errorf("unexpected data area with glyph padding: got:%v want:%v", padded, want)
+	}
+}
+
+func TestPlotters(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f := plotter.NewFunction(math.Sin)
+	g := plotter.NewGrid()
+	p.Add(f, g)
+
+	got := p.Plotters()
+	if len(got) != 2 || got[0] != f || got[1] != g {
+		t.Fatalf("unexpected plotters: %v", got)
+	}
+	got[0] = nil
+	if p.Plotters()[0] != f {
+		t.Error("modifying the returned plotters modified the plot")
+	}
+}
+
+func TestTransparentBackground(t *testing.T) {
+	for _, test := range []struct {
+		bg    color.Color
+		alpha uint32
+	}{
+		{bg: color.White, alpha: 0xffff},
+		{bg: nil, alpha: 0},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.BackgroundColor = test.bg
+
+		w, err := p.WriterTo(100, 100, "png")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var buf bytes.Buffer
+		_, err = w.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		img, err := png.Decode(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, _, _, a := img.At(0, 0).RGBA()
+		if a != test.alpha {
+			t.Errorf("unexpected corner alpha for background %v: got:%#x want:%#x", test.bg, a, test.alpha)
+		}
+	}
+}
+
+func TestTransparentBackgroundVector(t *testing.T) {
+	// An empty plot has no filled shapes other
+	// than its background, so any fill in the
+	// output is a background fill.
+	for _, test := range []struct {
+		format string
+		isFill func(out []byte) bool
+	}{
+		{
+			format: "svg",
+			isFill: func(out []byte) bool {
+				return bytes.Contains(out, []byte(`style="fill:#`))
+			},
+		},
+		{
+			format: "pdf",
+			isFill: func(out []byte) bool {
+				for _, content := range pdfContents(t, out) {
+					for _, l := range strings.Split(content, "\n") {
+						if l == "f" {
+							return true
+						}
+					}
+				}
+				return false
+			},
+		},
+	} {
+		for _, bg := range []color.Color{color.White, nil} {
+			p, err := plot.New()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			p.BackgroundColor = bg
+
+			w, err := p.WriterTo(100, 100, test.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var buf bytes.Buffer
+			_, err = w.WriteTo(&buf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got, want := test.isFill(buf.Bytes()), bg != nil; got != want {
+				t.Errorf("unexpected background fill in %s output for background %v: got:%t want:%t",
+					test.format, bg, got, want)
+			}
+		}
+	}
+
+	// Nor is any fill drawn on other canvases.
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.BackgroundColor = nil
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 100, 100)
+	p.Draw(c)
+	for _, a := range r.Actions {
+		if f, ok := a.(*recorder.Fill); ok {
+			t.Errorf("unexpected fill with nil background: %v", f.Path)
+		}
+	}
+}
+
+// pdfContents returns the decompressed text content
+// streams of a PDF document.
+func pdfContents(t *testing.T, doc []byte) []string {
+	var contents []string
+	for _, m := range regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`).FindAllSubmatch(doc, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			continue
+		}
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatalf("unexpected error decompressing pdf stream: %v", err)
+		}
+		if bytes.Contains(b, []byte("BT ")) {
+			contents = append(contents, string(b))
+		}
+	}
+	if len(contents) == 0 {
+		t.Fatal("no content streams in pdf output")
+	}
+	return contents
+}
+
+func TestDataBackground(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.BackgroundColor = color.White
+	p.DataBackgroundColor = color.Transparent
+
+	w, err := p.WriterTo(100, 100, "png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	_, err = w.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		x, y  int
+		alpha uint32
+	}{
+		{x: 0, y: 0, alpha: 0xffff},
+		{x: 10, y: 50, alpha: 0xffff},
+		{x: 70, y: 30, alpha: 0},
+	} {
+		_, _, _, a := img.At(test.x, test.y).RGBA()
+		if a != test.alpha {
+			t.Errorf("unexpected alpha at (%d, %d): got:%#x want:%#x", test.x, test.y, a, test.alpha)
+		}
+	}
+}
+
+func TestDataURI(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	uri, err := p.DataURI(100, 100, "PNG")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const prefix = "data:image/png;base64,"
+	if !strings.HasPrefix(uri, prefix) {
+		t.Fatalf("unexpected data URI prefix: got:%q want:%q", uri[:len(prefix)], prefix)
+	}
+	b, err := base64.StdEncoding.DecodeString(uri[len(prefix):])
+	if err != nil {
+		t.Fatalf("unexpected error decoding base64: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("unexpected error decoding image: %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(133, 133) {
+		t.Errorf("unexpected image size: got:%v want:%v", got, image.Pt(133, 133))
+	}
+
+	uri, err = p.DataURI(100, 100, "svg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(uri, "data:image/svg+xml;base64,") {
+		t.Errorf("unexpected data URI prefix for svg: %q", uri[:30])
+	}
+
+	_, err = p.DataURI(100, 100, "bmp")
+	if err == nil {
+		t.Error("expected error for unsupported format")
+	}
+
+	// Registered formats are given their MIME type.
+	draw.RegisterFormat("datauri", "image/x-datauri", func(w, h vg.Length, bg color.Color) vg.CanvasWriterTo {
+		return vgimg.PngCanvas{Canvas: vgimg.New(w, h)}
+	})
+	uri, err = p.DataURI(100, 100, "datauri")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(uri, "data:image/x-datauri;base64,") {
+		t.Errorf("unexpected data URI prefix for registered format: %q", uri[:30])
+	}
+}
+
+func TestDrawLayers(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "title"
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Color = color.NRGBA{R: 255, A: 255}
+	p.Add(l)
+
+	var whole, decorations, data recorder.Canvas
+	p.Draw(draw.NewCanvas(&whole, 100, 100))
+	p.DrawDecorations(draw.NewCanvas(&decorations, 100, 100))
+	p.DrawPlotters(draw.NewCanvas(&data, 100, 100))
+
+	want := len(whole.Actions)
+	got := len(decorations.Actions) + len(data.Actions)
+	if got != want {
+		t.Errorf("unexpected number of actions: got:%d want:%d", got, want)
+	}
+	var found bool
+	for _, a := range data.Actions {
+		if sc, ok := a.(*recorder.SetColor); ok && sc.Color == l.Color {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("line not drawn by DrawPlotters")
+	}
+	for _, a := range decorations.Actions {
+		if sc, ok := a.(*recorder.SetColor); ok && sc.Color == l.Color {
+			t.Error("line drawn by DrawDecorations")
+		}
+	}
+}
+
+// countingTicker is a Ticker that counts the
+// number of times its Ticks method is called.
+type countingTicker struct {
+	n *int
+}
+
+func (t countingTicker) Ticks(min, max float64) []plot.Tick {
+	*t.n++
+	return plot.DefaultTicks{}.Ticks(min, max)
+}
+
+func TestCacheLayout(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+	var n int
+	p.X.Tick.Marker = countingTicker{n: &n}
+	p.CacheLayout = true
+
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 100, 100)
+	p.Draw(c)
+	first := n
+	p.Draw(c)
+	if n != first {
+		t.Errorf("ticks recomputed with unchanged layout: got %d calls want %d", n, first)
+	}
+
+	p.X.Max = 20
+	n = 0
+	p.Draw(c)
+	if n == 0 {
+		t.Error("ticks not recomputed after range change")
+	}
+
+	p.CacheLayout = false
+	var u recorder.Canvas
+	p.Draw(draw.NewCanvas(&u, 100, 100))
+	var cached recorder.Canvas
+	p.CacheLayout = true
+	p.Draw(draw.NewCanvas(&cached, 100, 100))
+	if !reflect.DeepEqual(u.Actions, cached.Actions) {
+		t.Error("cached layout differs from uncached layout")
+	}
+
+	// Wrapped tick labels are cached too.
+	p.X.Tick.Wrap = true
+	p.Draw(c)
+	n = 0
+	p.Draw(c)
+	if n != 0 {
+		t.Errorf("ticks recomputed with unchanged layout of wrapped labels: got %d calls want 0", n)
+	}
+
+	// Any change to the style of the labels
+	// invalidates the cached layout.
+	p.X.Tick.Label.XAlign = draw.XLeft
+	n = 0
+	p.Draw(c)
+	if n == 0 {
+		t.Error("ticks not recomputed after tick label style change")
+	}
+	p.X.Label.YAlign = draw.YCenter
+	n = 0
+	p.Draw(c)
+	if n == 0 {
+		t.Error("ticks not recomputed after axis label style change")
+	}
+
+	// Labels with colors that are not comparable
+	// are not cached.
+	p.X.Tick.Label.Color = grayColor{0x40}
+	p.Draw(c)
+	n = 0
+	p.Draw(c)
+	if n == 0 {
+		t.Error("ticks not recomputed with incomparable tick label color")
+	}
+}
+
+// grayColor is a color.Color that is not comparable.
+type grayColor []uint8
+
+func (g grayColor) RGBA() (r, gr, b, a uint32) {
+	return color.Gray{Y: g[0]}.RGBA()
+}
+
+func TestMinSize(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+	w, h, err := p.MinSize()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w <= 0 || h <= 0 {
+		t.Fatalf("unexpected size: got w=%v h=%v", w, h)
+	}
+
+	p.Title.Text = "A title that is much wider than the data area of the plot"
+	p.Legend.Add("a legend entry with a long name", plotter.NewFunction(math.Sin))
+	tw, th, err := p.MinSize()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tw < p.Title.Width(p.Title.Text) {
+		t.Errorf("size narrower than title: got %v want at least %v", tw, p.Title.Width(p.Title.Text))
+	}
+	if th <= h {
+		t.Errorf("size not taller with title: got %v want more than %v", th, h)
+	}
+
+	p.Margin = 5
+	mw, mh, err := p.MinSize()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mw != tw+10 || mh != th+10 {
+		t.Errorf("unexpected size with margin: got w=%v h=%v want w=%v h=%v", mw, mh, tw+10, th+10)
+	}
+
+	p.X.Min = math.NaN()
+	if _, _, err := p.MinSize(); err == nil {
+		t.Error("expected error for NaN range")
+	}
+}
+
+func TestMirrorTicks(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+	p.X.Tick.Marker = plot.ConstantTicks{{Value: 5, Label: "5"}}
+	p.Y.Tick.Marker = plot.ConstantTicks{{Value: 5, Label: "5"}}
+
+	count := func() (strokes, labels int) {
+		var r recorder.Canvas
+		p.Draw(draw.NewCanvas(&r, 100, 100))
+		for _, a := range r.Actions {
+			switch a.(type) {
+			case *recorder.Stroke:
+				strokes++
+			case *recorder.FillString:
+				labels++
+			}
+		}
+		return strokes, labels
+	}
+	strokes, labels := count()
+
+	p.X.Tick.Mirror = true
+	p.Y.Tick.Mirror = true
+	mStrokes, mLabels := count()
+	if mStrokes != strokes+2 {
+		t.Errorf("unexpected number of strokes with mirrored ticks: got:%d want:%d", mStrokes, strokes+2)
+	}
+	if mLabels != labels {
+		t.Errorf("unexpected number of labels with mirrored ticks: got:%d want:%d", mLabels, labels)
+	}
+
+	dc := p.DataCanvas(draw.NewCanvas(new(recorder.Canvas), 100, 100))
+	var r recorder.Canvas
+	p.Draw(draw.NewCanvas(&r, 100, 100))
+	var last []vg.Path
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok {
+			last = append(last, s.Path)
+		}
+	}
+	top := last[len(last)-2]
+	if top[0].Pos.Y != dc.Max.Y || top[1].Pos.Y != dc.Max.Y-p.X.Tick.Length {
+		t.Errorf("unexpected mirrored x tick: got:%v", top)
+	}
+	right := last[len(last)-1]
+	if right[0].Pos.X != dc.Max.X || right[1].Pos.X != dc.Max.X-p.Y.Tick.Length {
+		t.Errorf("unexpected mirrored y tick: got:%v", right)
+	}
+
+	// Mirrored minor ticks are half as long, and
+	// also start at the edge of the data area.
+	p.X.Tick.Marker = plot.ConstantTicks{{Value: 5, Label: "5"}, {Value: 2.5}}
+	p.Y.Tick.Marker = plot.ConstantTicks{{Value: 5, Label: "5"}, {Value: 2.5}}
+	r = recorder.Canvas{}
+	p.Draw(draw.NewCanvas(&r, 100, 100))
+	last = last[:0]
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok {
+			last = append(last, s.Path)
+		}
+	}
+	top = last[len(last)-3]
+	if top[0].Pos.Y != dc.Max.Y || top[1].Pos.Y != dc.Max.Y-p.X.Tick.Length/2 {
+		t.Errorf("unexpected mirrored minor x tick: got:%v", top)
+	}
+	right = last[len(last)-1]
+	if right[0].Pos.X != dc.Max.X || right[1].Pos.X != dc.Max.X-p.Y.Tick.Length/2 {
+		t.Errorf("unexpected mirrored minor y tick: got:%v", right)
+	}
+}
+
+func TestCanvasSize(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "title"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+	p.Margin = 3
+	p.Y.Tick.RelativeLength = 0.02
+	s, err := plotter.NewScatter(plotter.XYs{{X: 0, Y: 0}, {X: 1000, Y: 1000}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+
+	want := vg.Point{X: 150, Y: 100}
+	w, h := p.CanvasSize(want)
+	c := draw.NewCanvas(new(recorder.Canvas), w, h)
+	got := p.DataCanvas(c).Size()
+	if math.Abs(float64(got.X-want.X)) > 1e-6 || math.Abs(float64(got.Y-want.Y)) > 1e-6 {
+		t.Errorf("unexpected data area size: got:%v want:%v", got, want)
+	}
+
+	p.DataSize = want
+	wt, err := p.WriterTo(1, 1, "png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := wt.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := img.Bounds().Dx(), int(w.Dots(vgimg.DefaultDPI)+0.5); got != want {
+		t.Errorf("unexpected image width: got:%d want:%d", got, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	q, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f := plotter.NewFunction(math.Sin)
+	g := plotter.NewFunction(math.Cos)
+	p.Add(f)
+	p.ExtendRange(0, 1, -1, 1)
+	q.Add(g)
+	q.ExtendRange(-2, 0.5, 0, 3)
+
+	if err := p.Merge(q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := p.Plotters()
+	if len(got) != 2 || got[0] != f || got[1] != g {
+		t.Errorf("unexpected plotters after merge: %v", got)
+	}
+	if p.X.Min != -2 || p.X.Max != 1 || p.Y.Min != -1 || p.Y.Max != 3 {
+		t.Errorf("unexpected ranges after merge: x=[%v, %v] y=[%v, %v]", p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+	if len(q.Plotters()) != 1 {
+		t.Error("merged plot modified")
+	}
+
+	q.NominalX("a", "b")
+	if err := p.Merge(q); err == nil {
+		t.Error("expected error merging nominal and numeric axes")
+	}
+	if len(p.Plotters()) != 2 {
+		t.Error("plot modified by failed merge")
+	}
+}
+
+func TestTitleBox(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "title"
+	c := draw.New(vgimg.New(200, 200))
+	plain := p.DataCanvas(c)
+
+	bg := color.NRGBA{G: 255, A: 255}
+	p.Title.BackgroundColor = bg
+	p.Title.BoxPadding = vg.Points(4)
+	boxed := p.DataCanvas(c)
+
+	// The box is taller than the plain title by the
+	// descent of the font and the box padding.
+	want := p.Title.Font.Extents().Descent*2 + 2*p.Title.BoxPadding
+	if got := plain.Max.Y - boxed.Max.Y; math.Abs(float64(got-want)) > 1e-9 {
+		t.Errorf("unexpected title reservation difference: got:%v want:%v", got, want)
+	}
+
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 200, 200))
+	var filled, text bool
+	for i, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.Fill:
+			if i > 0 {
+				if sc, ok := rec.Actions[i-1].(*recorder.SetColor); ok && sc.Color == bg {
+					filled = true
+				}
+			}
+		case *recorder.FillString:
+			if a.String == p.Title.Text {
+				if !filled {
+					t.Error("title drawn before its background")
+				}
+				text = true
+			}
+		}
+	}
+	if !filled {
+		t.Error("title background not filled")
+	}
+	if !text {
+		t.Error("title text not drawn")
+	}
+}
+
+func TestDrawTiles(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "tiles"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 3}, {X: 2, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+
+	const (
+		w, h = 3 * vg.Inch, 2 * vg.Inch
+		dpi  = 96
+	)
+	c := vgimg.NewWith(vgimg.UseWH(w, h), vgimg.UseDPI(dpi))
+	p.Draw(draw.New(c))
+	whole := c.Image()
+
+	tiles, err := p.DrawTiles(w, h, 2, 3, dpi)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var y0 int
+	for i, row := range tiles {
+		var x0 int
+		for j, tile := range row {
+			b := tile.Bounds()
+			for y := 0; y < b.Dy(); y++ {
+				for x := 0; x < b.Dx(); x++ {
+					// Allow for small differences in
+					// anti-aliasing at the tile offsets.
+					const tol = 0x1000
+					gr, gg, gb, ga := tile.At(x, y).RGBA()
+					wr, wg, wb, wa := whole.At(x0+x, y0+y).RGBA()
+					if absDiff(gr, wr) > tol || absDiff(gg, wg) > tol || absDiff(gb, wb) > tol || absDiff(ga, wa) > tol {
+						t.Fatalf("tile %d,%d differs at %d,%d: got:%v want:%v",
+							i, j, x, y, tile.At(x, y), whole.At(x0+x, y0+y))
+					}
+				}
+			}
+			x0 += b.Dx()
+		}
+		if x0 != whole.Bounds().Dx() {
+			t.Errorf("unexpected row %d width: got:%d want:%d", i, x0, whole.Bounds().Dx())
+		}
+		y0 += row[0].Bounds().Dy()
+	}
+	if y0 != whole.Bounds().Dy() {
+		t.Errorf("unexpected total height: got:%d want:%d", y0, whole.Bounds().Dy())
+	}
+
+	if _, err := p.DrawTiles(w, h, 0, 1, dpi); err == nil {
+		t.Error("expected error for empty tile grid")
+	}
+}
+
+func absDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// annotation is a plotter with a data range that
+// may be excluded from the plot's axis ranges.
+type annotation struct {
+	exclude bool
+}
+
+func (annotation) Plot(draw.Canvas, *plot.Plot) {}
+
+func (annotation) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return -100, 100, -100, 100
+}
+
+func (a annotation) NoRange() bool { return a.exclude }
+
+func TestNoRange(t *testing.T) {
+	for _, exclude := range []bool{true, false} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s, err := plotter.NewScatter(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 2}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		a := annotation{exclude: exclude}
+		p.Add(s, a)
+
+		want := [4]float64{0, 1, 0, 2}
+		if !exclude {
+			want = [4]float64{-100, 100, -100, 100}
+		}
+		got := [4]float64{p.X.Min, p.X.Max, p.Y.Min, p.Y.Max}
+		if got != want {
+			t.Errorf("unexpected axis ranges with exclude=%t: got:%v want:%v", exclude, got, want)
+		}
+		if n := len(p.Plotters()); n != 2 {
+			t.Errorf("unexpected number of plotters with exclude=%t: got:%d want:2", exclude, n)
+		}
+	}
+}
+
+func TestDataClip(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+
+	var clipped vg.Path
+	p.DataClip = func(c draw.Canvas) vg.Path {
+		center := c.Center()
+		r := c.Size().X / 2
+		var path vg.Path
+		path.Move(vg.Point{X: center.X + r, Y: center.Y})
+		path.Arc(center, r, 0, 2*math.Pi)
+		path.Close()
+		clipped = path
+		return path
+	}
+
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 100, 100))
+
+	var depth, clipDepth int
+	var sawClip, sawLine bool
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.Push:
+			depth++
+		case *recorder.Pop:
+			if depth == clipDepth {
+				clipDepth = 0
+			}
+			depth--
+		case *recorder.Clip:
+			if !reflect.DeepEqual(a.Path, clipped) {
+				t.Errorf("unexpected clip path: got:%v want:%v", a.Path, clipped)
+			}
+			sawClip = true
+			clipDepth = depth
+		case *recorder.Stroke:
+			if len(a.Path) == 2 && a.Path[0].Pos.X < a.Path[1].Pos.X && a.Path[0].Pos.Y < a.Path[1].Pos.Y {
+				if clipDepth == 0 {
+					t.Error("line drawn outside the clipped region")
+				}
+				sawLine = true
+			}
+		}
+	}
+	if !sawClip {
+		t.Error("clip not applied")
+	}
+	if !sawLine {
+		t.Error("line not drawn")
+	}
+}
+
+func TestPercentileRange(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.PercentileRange(2, 98); err == nil {
+		t.Error("expected error for plot without data")
+	}
+
+	xys := make(plotter.XYs, 101)
+	for i := range xys {
+		xys[i].X = float64(i)
+		xys[i].Y = float64(2 * i)
+	}
+	// Outliers.
+	xys[0].Y = -1e6
+	xys[100].X = 1e6
+	s, err := plotter.NewScatter(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+	if err := p.PercentileRange(2, 98); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := [4]float64{p.X.Min, p.X.Max, p.Y.Min, p.Y.Max}
+	want := [4]float64{2, 98, 4, 196}
+	if got != want {
+		t.Errorf("unexpected ranges: got:%v want:%v", got, want)
+	}
+	if err := p.PercentileRange(50, 10); err == nil {
+		t.Error("expected error for invalid percentiles")
+	}
+
+	// Drawing with data outside the range must not fail.
+	p.Draw(draw.New(vgimg.New(100, 100)))
+}
+
+func TestDrawImage(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Frame"
+	p.Add(plotter.NewFunction(math.Sin))
+	p.X.Min, p.X.Max = 0, 6
+	p.Y.Min, p.Y.Max = -1, 1
+
+	const dpi = 96
+	want := vgimg.NewWith(vgimg.UseWH(2*vg.Inch, vg.Inch), vgimg.UseDPI(dpi))
+	p.Draw(draw.New(want))
+
+	img := image.NewRGBA(image.Rect(0, 0, 2*dpi, dpi))
+	for i := range img.Pix {
+		img.Pix[i] = 0x7f
+	}
+	for frame := 0; frame < 2; frame++ {
+		if err := p.DrawImage(img, dpi); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(img.Pix, want.Image().(*image.RGBA).Pix) {
+			t.Errorf("unexpected image for frame %d", frame)
+		}
+	}
+
+	// Drawing into a sub-image must fill only its bounds.
+	r := image.Rect(dpi/2, dpi/4, dpi/2+2*dpi, dpi/4+dpi)
+	large := image.NewRGBA(image.Rect(0, 0, 3*dpi, 2*dpi))
+	for i := range large.Pix {
+		large.Pix[i] = 0x7f
+	}
+	sub := large.SubImage(r).(*image.RGBA)
+	if err := p.DrawImage(sub, dpi); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantImg := want.Image()
+	for y := 0; y < large.Bounds().Dy(); y++ {
+		for x := 0; x < large.Bounds().Dx(); x++ {
+			got := large.RGBAAt(x, y)
+			pt := image.Pt(x, y)
+			if !pt.In(r) {
+				if got != (color.RGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f}) {
+					t.Fatalf("unexpected pixel outside sub-image at %v: %v", pt, got)
+				}
+				continue
+			}
+			wantC := color.RGBAModel.Convert(wantImg.At(x-r.Min.X, y-r.Min.Y))
+			if got != wantC {
+				t.Fatalf("unexpected pixel in sub-image at %v: got:%v want:%v", pt, got, wantC)
+			}
+		}
+	}
+
+	if err := p.DrawImage(img, 0); err == nil {
+		t.Errorf("expected error for zero dpi")
+	}
+	if err := p.DrawImage(&image.RGBA{}, dpi); err == nil {
+		t.Errorf("expected error for empty image")
+	}
+}
+
+// canvasRecorder records the data area that
+// it is drawn to.
+type canvasRecorder struct {
+	rect *vg.Rectangle
+}
+
+func (r canvasRecorder) Plot(c draw.Canvas, _ *plot.Plot) { *r.rect = c.Rectangle }
+
+func TestLayout(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Layout"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = -1, 1
+	p.X.Tick.Marker = plot.ConstantTicks{{Value: -5, Label: "out"}, {Value: 0, Label: "0"}, {Value: 5}, {Value: 10, Label: "10"}}
+	p.Legend.Add("first")
+	p.Legend.Add("second")
+	p.Legend.Top = true
+
+	var drawn vg.Rectangle
+	p.Add(canvasRecorder{rect: &drawn})
+
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 300, 200)
+	l := p.Layout(c)
+	p.Draw(c)
+
+	if l.DataArea != drawn {
+		t.Errorf("unexpected data area: got:%v want:%v", l.DataArea, drawn)
+	}
+	if l.X.Min != 0 || l.X.Max != 10 || l.X.Label != "x" {
+		t.Errorf("unexpected x axis: got:%+v", l.X)
+	}
+	wantTicks := []plot.Tick{{Value: 0, Label: "0"}, {Value: 5}, {Value: 10, Label: "10"}}
+	if !reflect.DeepEqual(l.X.Ticks, wantTicks) {
+		t.Errorf("unexpected x ticks: got:%v want:%v", l.X.Ticks, wantTicks)
+	}
+	if l.Y.Label != "y" || len(l.Y.Ticks) == 0 {
+		t.Errorf("unexpected y axis: got:%+v", l.Y)
+	}
+
+	if len(l.Legends) != 1 {
+		t.Fatalf("unexpected number of legends: got:%d want:1", len(l.Legends))
+	}
+	if got, want := l.Legends[0].Entries, []string{"first", "second"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected legend entries: got:%q want:%q", got, want)
+	}
+	lr := l.Legends[0].Rectangle
+	if lr.Max.Y > c.Max.Y || lr.Min.X < c.Min.X || lr.Max.X > c.Max.X || lr.Size().Y <= 0 {
+		t.Errorf("unexpected legend rectangle: got:%v", lr)
+	}
+}
+
+func TestLegendInset(t *testing.T) {
+	for _, horizontal := range []bool{false, true} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 10
+		p.Y.Min, p.Y.Max = 0, 10
+		p.Legend.Add("first")
+		p.Legend.Add("second")
+		p.Legend.Top = true
+		p.Legend.Horizontal = horizontal
+
+		var drawn vg.Rectangle
+		p.Add(canvasRecorder{rect: &drawn})
+
+		var r recorder.Canvas
+		c := draw.NewCanvas(&r, 300, 200)
+		overlay := p.Layout(c)
+
+		p.Legend.Inset = true
+		inset := p.Layout(c)
+		p.Draw(c)
+
+		if inset.DataArea != drawn {
+			t.Errorf("horizontal=%t: unexpected drawn data area: got:%v want:%v", horizontal, drawn, inset.DataArea)
+		}
+		size := inset.Legends[0].Rectangle.Size()
+		if horizontal {
+			if inset.DataArea.Max.Y > overlay.DataArea.Max.Y-size.Y {
+				t.Errorf("horizontal=%t: data area top under legend: got:%v want:<=%v", horizontal, inset.DataArea.Max.Y, overlay.DataArea.Max.Y-size.Y)
+			}
+			if inset.DataArea.Max.X != overlay.DataArea.Max.X {
+				t.Errorf("horizontal=%t: unexpected data area right: got:%v want:%v", horizontal, inset.DataArea.Max.X, overlay.DataArea.Max.X)
+			}
+		} else {
+			if inset.DataArea.Max.X > overlay.DataArea.Max.X-size.X {
+				t.Errorf("horizontal=%t: data area right under legend: got:%v want:<=%v", horizontal, inset.DataArea.Max.X, overlay.DataArea.Max.X-size.X)
+			}
+			if inset.DataArea.Max.Y != overlay.DataArea.Max.Y {
+				t.Errorf("horizontal=%t: unexpected data area top: got:%v want:%v", horizontal, inset.DataArea.Max.Y, overlay.DataArea.Max.Y)
+			}
+		}
+	}
+}
+
+func TestLegendOutside(t *testing.T) {
+	for _, test := range []struct {
+		horizontal, top, left bool
+	}{
+		{horizontal: true},
+		{horizontal: true, top: true},
+		{},
+		{left: true},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.X.Min, p.X.Max = 0, 10
+		p.Y.Min, p.Y.Max = 0, 10
+		p.Legend.Add("first")
+		p.Legend.Add("second")
+		p.Legend.Horizontal = test.horizontal
+		p.Legend.Top = test.top
+		p.Legend.Left = test.left
+
+		var drawn vg.Rectangle
+		p.Add(canvasRecorder{rect: &drawn})
+
+		c := draw.NewCanvas(new(recorder.Canvas), 300, 200)
+		overlay := p.Layout(c)
+		minW, minH, err := p.MinSize()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		p.Legend.Outside = true
+		outside := p.Layout(c)
+		p.Draw(c)
+		if outside.DataArea != drawn {
+			t.Errorf("%+v: unexpected drawn data area: got:%v want:%v", test, drawn, outside.DataArea)
+		}
+
+		// The legend is placed beyond the axes, which
+		// keep their sizes, and the data area shrinks by
+		// at least the size of the legend.
+		lr := outside.Legends[0].Rectangle
+		size := lr.Size()
+		da, oa := outside.DataArea, overlay.DataArea
+		var ok bool
+		switch {
+		case test.horizontal && test.top:
+			ok = lr.Min.Y > da.Max.Y && da.Max.Y <= oa.Max.Y-size.Y
+		case test.horizontal:
+			ok = lr.Max.Y <= da.Min.Y-(oa.Min.Y-c.Min.Y) && da.Min.Y >= oa.Min.Y+size.Y
+		case test.left:
+			ok = lr.Max.X <= da.Min.X-(oa.Min.X-c.Min.X) && da.Min.X >= oa.Min.X+size.X
+		default:
+			ok = lr.Min.X > da.Max.X && da.Max.X <= oa.Max.X-size.X
+		}
+		if !ok {
+			t.Errorf("%+v: unexpected outside legend placement: legend:%v data area:%v overlay data area:%v",
+				test, lr, da, oa)
+		}
+
+		w, h, err := p.MinSize()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if test.horizontal && h <= minH || !test.horizontal && w <= minW {
+			t.Errorf("%+v: minimum size not increased: got:%vx%v overlay:%vx%v", test, w, h, minW, minH)
+		}
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Metadata = vg.Metadata{
+		Title:    "Results",
+		Author:   "Gopher",
+		Keywords: []string{"plot"},
+	}
+
+	for _, test := range []struct {
+		format string
+		want   []string
+	}{
+		{format: "svg", want: []string{"<title>Results</title>", "<dc:creator>Gopher</dc:creator>", "<dc:subject>plot</dc:subject>"}},
+		{format: "pdf", want: []string{"/Title ", "/Author ", "/Keywords "}},
+		{format: "png"},
+	} {
+		w, err := p.WriterTo(100, 100, test.format)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", test.format, err)
+		}
+		var buf bytes.Buffer
+		if _, err := w.WriteTo(&buf); err != nil {
+			t.Fatalf("unexpected error writing %s: %v", test.format, err)
+		}
+		for _, want := range test.want {
+			if !bytes.Contains(buf.Bytes(), []byte(want)) {
+				t.Errorf("missing %q in %s output", want, test.format)
+			}
+		}
+	}
+}
+
+// taggedRegion is a plotter that fills its data
+// range after tagging it with a link.
+type taggedRegion struct {
+	tagged *bool
+}
+
+func (r taggedRegion) Plot(c draw.Canvas, p *plot.Plot) {
+	trX, trY := p.Transforms(&c)
+	*r.tagged = c.Tag("region", "https://example.com/region")
+	c.FillPolygon(color.Black, []vg.Point{
+		{X: trX(0), Y: trY(0)},
+		{X: trX(1), Y: trY(0)},
+		{X: trX(1), Y: trY(1)},
+		{X: trX(0), Y: trY(1)},
+	})
+}
+
+func (taggedRegion) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 1, 0, 1
+}
+
+func TestTag(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var tagged bool
+	p.Add(taggedRegion{tagged: &tagged})
+
+	c := vgsvg.New(3*vg.Inch, 2*vg.Inch)
+	dc := draw.New(c)
+	dc.SetAlpha(0.5)
+	p.Draw(dc)
+	if !tagged {
+		t.Error("expected svg canvas to support tagging")
+	}
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+	for _, want := range []string{`<a xlink:href="https://example.com/region">`, `id="region"`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("missing %q in svg output", want)
+		}
+	}
+
+	p.Draw(draw.New(vgimg.New(3*vg.Inch, 2*vg.Inch)))
+	if tagged {
+		t.Error("unexpected tagging support for image canvas")
+	}
+}
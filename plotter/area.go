@@ -0,0 +1,105 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Area implements the Plotter interface, drawing an
+// area chart: the region between a line and a
+// horizontal baseline is filled.
+type Area struct {
+	// XYs is a copy of the points of the line
+	// bounding the area.
+	XYs
+
+	// Baseline is the y value that the area is
+	// filled down, or up, to.
+	Baseline float64
+
+	// FillColor is the color used to fill the area.
+	// If FillColor is nil then the area is not filled.
+	FillColor color.Color
+
+	// LineStyle is the style of the line drawn
+	// through the points.  If LineStyle is nil
+	// then the line is not drawn.
+	LineStyle *draw.LineStyle
+}
+
+// NewArea returns an Area filled with light gray down to
+// a baseline of zero, with the line through the points
+// drawn in the default line style.
+func NewArea(xys XYer) (*Area, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	sty := DefaultLineStyle
+	return &Area{
+		XYs:       data,
+		FillColor: color.Gray{Y: 200},
+		LineStyle: &sty,
+	}, nil
+}
+
+// Plot draws the Area, implementing the plot.Plotter
+// interface.  The fill is drawn before the line, and
+// both are clipped to the data area, so the baseline
+// may be outside of the range of the Y axis.
+func (a *Area) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	line := make([]vg.Point, len(a.XYs))
+	for i, p := range a.XYs {
+		line[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+
+	if a.FillColor != nil {
+		base := trY(a.Baseline)
+		poly := make([]vg.Point, 0, len(line)+2)
+		poly = append(poly, vg.Point{X: line[0].X, Y: base})
+		poly = append(poly, line...)
+		poly = append(poly, vg.Point{X: line[len(line)-1].X, Y: base})
+		c.FillPolygon(a.FillColor, c.ClipPolygonXY(poly))
+	}
+
+	if a.LineStyle != nil {
+		c.StrokeLines(*a.LineStyle, c.ClipLinesXY(line)...)
+	}
+}
+
+// DataRange returns the minimum and maximum x and y
+// values, implementing the plot.DataRanger interface.
+// The y range includes the baseline.
+func (a *Area) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(a)
+	return xmin, xmax, math.Min(ymin, a.Baseline), math.Max(ymax, a.Baseline)
+}
+
+// Thumbnail creates the thumbnail for the Area,
+// implementing the plot.Thumbnailer interface.
+func (a *Area) Thumbnail(c *draw.Canvas) {
+	if a.FillColor != nil {
+		points := []vg.Point{
+			{X: c.Min.X, Y: c.Min.Y},
+			{X: c.Min.X, Y: c.Max.Y},
+			{X: c.Max.X, Y: c.Max.Y},
+			{X: c.Max.X, Y: c.Min.Y},
+		}
+		c.FillPolygon(a.FillColor, c.ClipPolygonY(points))
+	}
+	if a.LineStyle != nil {
+		c.StrokeLine2(*a.LineStyle, c.Min.X, c.Max.Y, c.Max.X, c.Max.Y)
+	}
+}
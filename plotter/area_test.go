@@ -0,0 +1,75 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+// ExampleArea draws an area chart filled down to a
+// baseline of one, which crosses the data.
+func ExampleArea() {
+	pts := make(XYs, 40)
+	for i := range pts {
+		x := float64(i) / 4
+		pts[i].X, pts[i].Y = x, 1+math.Sin(x)*x/4
+	}
+
+	a, err := NewArea(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	a.Baseline = 1
+	a.FillColor = color.NRGBA{R: 128, G: 128, B: 255, A: 128}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Area"
+	p.Add(a)
+	p.Legend.Add("area", a)
+
+	err = p.Save(200, 200, "testdata/area.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestArea(t *testing.T) {
+	cmpimg.CheckPlot(ExampleArea, t, "area.png")
+}
+
+func TestAreaDataRange(t *testing.T) {
+	a, err := NewArea(XYs{{X: 0, Y: 2}, {X: 1, Y: 3}, {X: 2, Y: 5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		baseline   float64
+		ymin, ymax float64
+	}{
+		{baseline: 0, ymin: 0, ymax: 5},
+		{baseline: 4, ymin: 2, ymax: 5},
+		{baseline: 10, ymin: 2, ymax: 10},
+	} {
+		a.Baseline = test.baseline
+		xmin, xmax, ymin, ymax := a.DataRange()
+		if xmin != 0 || xmax != 2 || ymin != test.ymin || ymax != test.ymax {
+			t.Errorf("unexpected data range for baseline %v: got:[%v,%v]x[%v,%v] want:[0,2]x[%v,%v]",
+				test.baseline, xmin, xmax, ymin, ymax, test.ymin, test.ymax)
+		}
+	}
+
+	if _, err := NewArea(XYs{}); err != ErrNoData {
+		t.Errorf("unexpected error for no data: got:%v want:%v", err, ErrNoData)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Band implements the Plotter interface, drawing a filled
+// region between two boundary curves, such as a
+// confidence band.
+type Band struct {
+	// Top and Bottom are copies of the points of the
+	// upper and lower boundary curves of the band.
+	Top, Bottom XYs
+
+	// FillColor is the color used to fill the band.
+	// If FillColor is nil then the band is not filled.
+	FillColor color.Color
+
+	// LineStyle is the style of the outline drawn along
+	// the boundary curves of the band.  If LineStyle
+	// is nil then no outline is drawn.
+	LineStyle *draw.LineStyle
+}
+
+// NewBand returns a Band filled with light gray and drawn
+// without an outline, where top and bottom are the upper
+// and lower boundary curves of the band.
+func NewBand(top, bottom XYer) (*Band, error) {
+	t, err := CopyXYs(top)
+	if err != nil {
+		return nil, err
+	}
+	b, err := CopyXYs(bottom)
+	if err != nil {
+		return nil, err
+	}
+	if len(t) == 0 || len(b) == 0 {
+		return nil, ErrNoData
+	}
+	return &Band{
+		Top:       t,
+		Bottom:    b,
+		FillColor: color.Gray{Y: 200},
+	}, nil
+}
+
+// Plot draws the Band, implementing the plot.Plotter
+// interface.  The fill is drawn before the outline.
+func (b *Band) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	top := make([]vg.Point, len(b.Top))
+	for i, p := range b.Top {
+		top[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+	bottom := make([]vg.Point, len(b.Bottom))
+	for i, p := range b.Bottom {
+		bottom[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+
+	if b.FillColor != nil {
+		poly := make([]vg.Point, 0, len(top)+len(bottom))
+		poly = append(poly, top...)
+		for i := len(bottom) - 1; i >= 0; i-- {
+			poly = append(poly, bottom[i])
+		}
+		c.FillPolygon(b.FillColor, c.ClipPolygonXY(poly))
+	}
+
+	if b.LineStyle != nil {
+		c.StrokeLines(*b.LineStyle, c.ClipLinesXY(top)...)
+		c.StrokeLines(*b.LineStyle, c.ClipLinesXY(bottom)...)
+	}
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger
+// interface.
+func (b *Band) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(b.Top)
+	bxmin, bxmax, bymin, bymax := XYRange(b.Bottom)
+	return math.Min(xmin, bxmin), math.Max(xmax, bxmax),
+		math.Min(ymin, bymin), math.Max(ymax, bymax)
+}
+
+// Thumbnail creates the thumbnail for the Band,
+// implementing the plot.Thumbnailer interface.
+func (b *Band) Thumbnail(c *draw.Canvas) {
+	if b.FillColor != nil {
+		points := []vg.Point{
+			{X: c.Min.X, Y: c.Min.Y},
+			{X: c.Min.X, Y: c.Max.Y},
+			{X: c.Max.X, Y: c.Max.Y},
+			{X: c.Max.X, Y: c.Min.Y},
+		}
+		c.FillPolygon(b.FillColor, c.ClipPolygonY(points))
+	}
+	if b.LineStyle != nil {
+		c.StrokeLine2(*b.LineStyle, c.Min.X, c.Min.Y, c.Max.X, c.Min.Y)
+		c.StrokeLine2(*b.LineStyle, c.Min.X, c.Max.Y, c.Max.X, c.Max.Y)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleBand draws a confidence band around a curve,
+// with a dashed outline along the band's boundaries.
+func ExampleBand() {
+	const n = 20
+	mid := make(XYs, n)
+	top := make(XYs, n)
+	bottom := make(XYs, n)
+	for i := range mid {
+		x := float64(i) / 2
+		y := math.Sin(x)
+		mid[i].X, mid[i].Y = x, y
+		top[i].X, top[i].Y = x, y+0.2+0.05*x
+		bottom[i].X, bottom[i].Y = x, y-0.2-0.05*x
+	}
+
+	band, err := NewBand(top, bottom)
+	if err != nil {
+		log.Panic(err)
+	}
+	band.FillColor = color.NRGBA{R: 128, G: 128, B: 255, A: 128}
+	band.LineStyle = &draw.LineStyle{
+		Color:  color.NRGBA{B: 255, A: 255},
+		Width:  vg.Points(0.5),
+		Dashes: []vg.Length{vg.Points(2), vg.Points(2)},
+	}
+
+	line, err := NewLine(mid)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Band"
+	p.Add(band, line)
+	p.Legend.Add("band", band)
+
+	err = p.Save(200, 200, "testdata/band.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBand(t *testing.T) {
+	cmpimg.CheckPlot(ExampleBand, t, "band.png")
+}
@@ -98,8 +98,10 @@ func (b *BarChart) StackOn(on *BarChart) {
 // Plot implements the plot.Plotter interface.
 func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 	trCat, trVal := plt.Transforms(&c)
+	valAxis := &plt.Y
 	if b.Horizontal {
 		trCat, trVal = trVal, trCat
+		valAxis = &plt.X
 	}
 
 	for i, ht := range b.Values {
@@ -116,8 +118,12 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 		}
 		catMin = catMin - b.Width/2 + b.Offset
 		catMax := catMin + b.Width
+		// Bars extend from their base in either direction.
+		// A base outside of the axis range, such as zero
+		// on a log scale, is moved to the nearest end.
 		bottom := b.stackedOn.BarHeight(i)
-		valMin := trVal(bottom)
+		base := math.Max(valAxis.Min, math.Min(bottom, valAxis.Max))
+		valMin := trVal(base)
 		valMax := trVal(bottom + ht)
 
 		var pts []vg.Point
@@ -154,12 +160,14 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 }
 
 // DataRange implements the plot.DataRanger interface.
+// The value range always includes zero, the baseline
+// from which the bars are drawn.
 func (b *BarChart) DataRange() (xmin, xmax, ymin, ymax float64) {
 	catMin := b.XMin
 	catMax := catMin + float64(len(b.Values)-1)
 
-	valMin := math.Inf(1)
-	valMax := math.Inf(-1)
+	valMin := 0.0
+	valMax := 0.0
 	for i, val := range b.Values {
 		valBot := b.stackedOn.BarHeight(i)
 		valTop := valBot + val
@@ -173,17 +181,23 @@ func (b *BarChart) DataRange() (xmin, xmax, ymin, ymax float64) {
 }
 
 // GlyphBoxes implements the GlyphBoxer interface.
+// Each box is placed at the end of its bar, above the
+// baseline for positive bars and below it for negative
+// bars.
 func (b *BarChart) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	boxes := make([]plot.GlyphBox, len(b.Values))
 	for i := range b.Values {
 		cat := b.XMin + float64(i)
+		end := b.BarHeight(i)
 		if !b.Horizontal {
 			boxes[i].X = plt.X.Norm(cat)
+			boxes[i].Y = plt.Y.Norm(end)
 			boxes[i].Rectangle = vg.Rectangle{
 				Min: vg.Point{X: b.Offset - b.Width/2},
 				Max: vg.Point{X: b.Offset + b.Width/2},
 			}
 		} else {
+			boxes[i].X = plt.X.Norm(end)
 			boxes[i].Y = plt.Y.Norm(cat)
 			boxes[i].Rectangle = vg.Rectangle{
 				Min: vg.Point{Y: b.Offset - b.Width/2},
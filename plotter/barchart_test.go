@@ -7,12 +7,15 @@ package plotter
 import (
 	"image/color"
 	"log"
+	"math"
 	"math/rand"
 	"testing"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
 )
 
 func ExampleBarChart() {
@@ -271,3 +274,69 @@ func ExampleBarChart_positiveNegative() {
 func TestBarChart_positiveNegative(t *testing.T) {
 	cmpimg.CheckPlot(ExampleBarChart_positiveNegative, t, "barChart_positiveNegative.png")
 }
+
+func TestBarChartBaseline(t *testing.T) {
+	pos, err := NewBarChart(Values{1, 2}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	neg, err := NewBarChart(Values{-1, -3}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stacked, err := NewBarChart(Values{1, 1}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stacked.StackOn(pos)
+
+	for _, test := range []struct {
+		name       string
+		b          *BarChart
+		ymin, ymax float64
+	}{
+		{name: "positive", b: pos, ymin: 0, ymax: 2},
+		{name: "negative", b: neg, ymin: -3, ymax: 0},
+		{name: "stacked", b: stacked, ymin: 0, ymax: 3},
+	} {
+		_, _, ymin, ymax := test.b.DataRange()
+		if ymin != test.ymin || ymax != test.ymax {
+			t.Errorf("%s: unexpected range: got:[%v, %v] want:[%v, %v]", test.name, ymin, ymax, test.ymin, test.ymax)
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(pos, neg)
+	for i, box := range neg.GlyphBoxes(p) {
+		if want := p.Y.Norm(neg.Values[i]); box.Y != want {
+			t.Errorf("unexpected glyph box %d y: got:%v want:%v", i, box.Y, want)
+		}
+	}
+
+	// On a log scale the base of the bars, zero, is
+	// outside of the axis range.
+	p, err = plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(pos)
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Min, p.Y.Max = 0.1, 10
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 100, 100)
+	pos.Plot(c, p)
+	for _, a := range r.Actions {
+		fill, ok := a.(*recorder.Fill)
+		if !ok {
+			continue
+		}
+		for _, comp := range fill.Path {
+			if y := float64(comp.Pos.Y); math.IsInf(y, 0) || math.IsNaN(y) {
+				t.Errorf("unexpected non-finite bar coordinate: %v", comp.Pos)
+			}
+		}
+	}
+}
@@ -104,6 +104,79 @@ func NewBoxPlot(w vg.Length, loc float64, values Valuer) (*BoxPlot, error) {
 		return nil, err
 	}
 
+	b.setStyle(w)
+	if len(b.Values) == 0 {
+		b.Width = 0
+		b.GlyphStyle.Radius = 0
+		b.BoxStyle.Width = 0
+		b.MedianStyle.Width = 0
+		b.WhiskerStyle.Width = 0
+	}
+
+	return b, nil
+}
+
+// BoxSummary holds the statistics of a distribution
+// of values that are drawn by a BoxPlot, for data that
+// have already been summarized.
+type BoxSummary struct {
+	// Median is the median value of the data.
+	Median float64
+
+	// Quartile1 and Quartile3 are the first and
+	// third quartiles of the data respectively.
+	Quartile1, Quartile3 float64
+
+	// AdjLow and AdjHigh are the values to which
+	// the whiskers are drawn.
+	AdjLow, AdjHigh float64
+
+	// Outside are the values of the outliers,
+	// which are drawn as glyphs.
+	Outside []float64
+}
+
+// NewBoxPlotSummary returns a new BoxPlot that draws the
+// given summary of a distribution, in the same style as
+// NewBoxPlot.  The Values of the returned BoxPlot are the
+// outside values of the summary.
+//
+// An error is returned if a statistic is NaN or infinite,
+// or if the statistics are out of order.
+func NewBoxPlotSummary(w vg.Length, loc float64, s BoxSummary) (*BoxPlot, error) {
+	if w < 0 {
+		return nil, errors.New("Negative boxplot width")
+	}
+	if err := CheckFloats(s.Median, s.Quartile1, s.Quartile3, s.AdjLow, s.AdjHigh); err != nil {
+		return nil, err
+	}
+	if !(s.AdjLow <= s.Quartile1 && s.Quartile1 <= s.Median && s.Median <= s.Quartile3 && s.Quartile3 <= s.AdjHigh) {
+		return nil, errors.New("plotter: box plot summary statistics out of order")
+	}
+
+	b := new(BoxPlot)
+	b.Location = loc
+	b.Median = s.Median
+	b.Quartile1, b.Quartile3 = s.Quartile1, s.Quartile3
+	b.AdjLow, b.AdjHigh = s.AdjLow, s.AdjHigh
+	b.Min, b.Max = s.AdjLow, s.AdjHigh
+	b.Values = make(Values, len(s.Outside))
+	for i, v := range s.Outside {
+		if err := CheckFloats(v); err != nil {
+			return nil, err
+		}
+		b.Values[i] = v
+		b.Outside = append(b.Outside, i)
+		b.Min = math.Min(b.Min, v)
+		b.Max = math.Max(b.Max, v)
+	}
+	b.setStyle(w)
+	return b, nil
+}
+
+// setStyle sets the box width and the
+// default styles of the BoxPlot.
+func (b *BoxPlot) setStyle(w vg.Length) {
 	b.Width = w
 	b.CapWidth = 3 * w / 4
 
@@ -114,16 +187,6 @@ func NewBoxPlot(w vg.Length, loc float64, values Valuer) (*BoxPlot, error) {
 		Width:  vg.Points(0.5),
 		Dashes: []vg.Length{vg.Points(4), vg.Points(2)},
 	}
-
-	if len(b.Values) == 0 {
-		b.Width = 0
-		b.GlyphStyle.Radius = 0
-		b.BoxStyle.Width = 0
-		b.MedianStyle.Width = 0
-		b.WhiskerStyle.Width = 0
-	}
-
-	return b, nil
 }
 
 func newFiveStat(w vg.Length, loc float64, values Valuer) (fiveStatPlot, error) {
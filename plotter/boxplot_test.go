@@ -7,12 +7,14 @@ package plotter
 import (
 	"fmt"
 	"log"
+	"math"
 	"math/rand"
 	"testing"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
 )
 
 func ExampleBoxPlot() {
@@ -157,3 +159,64 @@ func TestBoxPlot(t *testing.T) {
 	cmpimg.CheckPlot(ExampleBoxPlot, t, "verticalBoxPlot.png",
 		"horizontalBoxPlot.png", "groupedBoxPlot.png")
 }
+
+// ExampleNewBoxPlotSummary draws box plots of distributions
+// that have already been summarized, on a nominal axis.
+func ExampleNewBoxPlotSummary() {
+	summaries := []BoxSummary{
+		{AdjLow: 1, Quartile1: 3, Median: 4, Quartile3: 6, AdjHigh: 9, Outside: []float64{12, 13.5}},
+		{AdjLow: 2, Quartile1: 2.5, Median: 5, Quartile3: 5.5, AdjHigh: 7},
+		{AdjLow: 4, Quartile1: 6, Median: 7, Quartile3: 8.5, AdjHigh: 10, Outside: []float64{0.5}},
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Summarized Box Plots"
+	for i, s := range summaries {
+		b, err := NewBoxPlotSummary(vg.Points(20), float64(i), s)
+		if err != nil {
+			log.Panic(err)
+		}
+		b.GlyphStyle.Shape = draw.CrossGlyph{}
+		p.Add(b)
+	}
+	p.NominalX("Alpha", "Beta", "Gamma")
+
+	err = p.Save(200, 200, "testdata/summaryBoxPlot.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestNewBoxPlotSummary(t *testing.T) {
+	cmpimg.CheckPlot(ExampleNewBoxPlotSummary, t, "summaryBoxPlot.png")
+
+	b, err := NewBoxPlotSummary(vg.Points(10), 1, BoxSummary{AdjLow: 1, Quartile1: 2, Median: 3, Quartile3: 4, AdjHigh: 5, Outside: []float64{-2, 8}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := b.DataRange()
+	if xmin != 1 || xmax != 1 || ymin != -2 || ymax != 8 {
+		t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[1, 1]x[-2, 8]", xmin, xmax, ymin, ymax)
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(b)
+	if n := len(b.GlyphBoxes(p)); n != 3 {
+		t.Errorf("unexpected number of glyph boxes: got:%d want:3", n)
+	}
+
+	for _, s := range []BoxSummary{
+		{AdjLow: 1, Quartile1: 3, Median: 2, Quartile3: 4, AdjHigh: 5},
+		{AdjLow: 1, Quartile1: 2, Median: 3, Quartile3: 4, AdjHigh: math.NaN()},
+		{AdjLow: 1, Quartile1: 2, Median: 3, Quartile3: 4, AdjHigh: 5, Outside: []float64{math.Inf(1)}},
+	} {
+		if _, err := NewBoxPlotSummary(vg.Points(10), 0, s); err == nil {
+			t.Errorf("expected error for summary %+v", s)
+		}
+	}
+}
@@ -86,3 +86,35 @@ func ExampleScatter_bubbles() {
 func TestNewBubbles(t *testing.T) {
 	cmpimg.CheckPlot(ExampleScatter_bubbles, t, "bubbles.png")
 }
+
+func TestBubbles(t *testing.T) {
+	data := XYZs{{X: 0, Y: 0, Z: 1}, {X: 1, Y: 2, Z: 3}, {X: 2, Y: 1, Z: 2}}
+	bs, err := NewBubbles(data, 2, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, want := range []vg.Length{2, 10, 6} {
+		if got := bs.radius(data[i].Z); got != want {
+			t.Errorf("unexpected radius for z=%v: got:%v want:%v", data[i].Z, got, want)
+		}
+	}
+
+	xmin, xmax, ymin, ymax := bs.DataRange()
+	if xmin != 0 || xmax != 2 || ymin != 0 || ymax != 2 {
+		t.Errorf("unexpected data range: got:%v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(bs)
+	boxes := bs.GlyphBoxes(p)
+	if got, want := boxes[1].Size().X, vg.Length(20); got != want {
+		t.Errorf("unexpected glyph box width for largest bubble: got:%v want:%v", got, want)
+	}
+
+	if _, err := NewBubbles(XYZs{}, 2, 10); err != ErrNoData {
+		t.Errorf("unexpected error for empty data: got:%v want:%v", err, ErrNoData)
+	}
+}
@@ -6,6 +6,7 @@ package plotter
 
 import (
 	"image"
+	"math"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/palette"
@@ -13,6 +14,9 @@ import (
 )
 
 // ColorBar is a plot.Plotter that draws a color bar legend for a ColorMap.
+// If the axis along the bar has a plot.LogScale, the colors
+// are sampled at logarithmically spaced values, as suits a
+// ColorMap returned by palette.Log.
 type ColorBar struct {
 	ColorMap palette.ColorMap
 
@@ -56,14 +60,29 @@ func (l *ColorBar) Plot(c draw.Canvas, p *plot.Plot) {
 	l.check()
 	colors := l.colors(c)
 	var pImg *Image
-	delta := (l.ColorMap.Max() - l.ColorMap.Min()) / float64(colors)
+	min, max := l.ColorMap.Min(), l.ColorMap.Max()
+	delta := (max - min) / float64(colors)
+	value := func(i int) float64 { return min + delta*float64(i) }
+
+	// The colors are sampled evenly along the axis, so
+	// that they lie at their values on a logarithmic axis.
+	axis := p.X
+	if l.Vertical {
+		axis = p.Y
+	}
+	if _, ok := axis.Scale.(plot.LogScale); ok {
+		if min <= 0 {
+			panic("plotter: non-positive ColorMap Min on logarithmic axis")
+		}
+		value = func(i int) float64 { return min * math.Pow(max/min, float64(i)/float64(colors)) }
+	}
 	if l.Vertical {
 		img := image.NewNRGBA64(image.Rectangle{
 			Min: image.Point{X: 0, Y: 0},
 			Max: image.Point{X: 1, Y: colors},
 		})
 		for i := 0; i < colors; i++ {
-			color, err := l.ColorMap.At(l.ColorMap.Min() + delta*float64(i))
+			color, err := l.ColorMap.At(value(i))
 			if err != nil {
 				panic(err)
 			}
@@ -76,7 +95,7 @@ func (l *ColorBar) Plot(c draw.Canvas, p *plot.Plot) {
 			Max: image.Point{X: colors, Y: 1},
 		})
 		for i := 0; i < colors; i++ {
-			color, err := l.ColorMap.At(l.ColorMap.Min() + delta*float64(i))
+			color, err := l.ColorMap.At(value(i))
 			if err != nil {
 				panic(err)
 			}
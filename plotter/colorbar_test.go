@@ -11,6 +11,7 @@ import (
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/palette/moreland"
 )
 
@@ -65,6 +66,37 @@ func TestColorBar_horizontal_log(t *testing.T) {
 	cmpimg.CheckPlot(ExampleColorBar_horizontal_log, t, "colorBarHorizontalLog.png")
 }
 
+// ExampleColorBar_horizontal_logColorMap draws a color bar for a
+// logarithmic ColorMap on a logarithmic axis, so that the colors
+// are spread evenly across the decades.
+func ExampleColorBar_horizontal_logColorMap() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	colorMap, err := moreland.NewLuminance([]color.Color{color.Black, color.White})
+	if err != nil {
+		log.Panic(err)
+	}
+	l := &ColorBar{ColorMap: palette.Log(colorMap)}
+	l.ColorMap.SetMin(1)
+	l.ColorMap.SetMax(100)
+	p.Add(l)
+	p.HideY()
+	p.X.Padding = 0
+	p.Title.Text = "Title"
+	p.X.Scale = plot.LogScale{}
+	p.X.Tick.Marker = plot.LogTicks{}
+
+	if err = p.Save(300, 48, "testdata/colorBarHorizontalLogColorMap.png"); err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestColorBar_horizontal_logColorMap(t *testing.T) {
+	cmpimg.CheckPlot(ExampleColorBar_horizontal_logColorMap, t, "colorBarHorizontalLogColorMap.png")
+}
+
 func ExampleColorBar_vertical() {
 	p, err := plot.New()
 	if err != nil {
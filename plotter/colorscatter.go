@@ -0,0 +1,117 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ColorScatter implements the Plotter interface, drawing
+// a glyph for each of a set of x, y, z triples where the
+// color of the glyph is given by the z value.
+type ColorScatter struct {
+	// XYZs is a copy of the points for this scatter.
+	XYZs
+
+	// ColorMap maps the z values to the colors of
+	// the glyphs.  Values outside the range of the
+	// ColorMap are given the color of the nearest end
+	// of the range.  A ColorBar drawn with the same
+	// ColorMap shows the meaning of the colors.
+	ColorMap palette.ColorMap
+
+	// GlyphStyle is the style of the glyphs drawn
+	// at each point.  Its Color is not used.
+	draw.GlyphStyle
+}
+
+// NewColorScatter returns a ColorScatter that uses the
+// default glyph style and the given ColorMap.  The range
+// of the ColorMap is set to the range of the z values.
+func NewColorScatter(xyz XYZer, cm palette.ColorMap) (*ColorScatter, error) {
+	data, err := CopyXYZs(xyz)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	min, max := data[0].Z, data[0].Z
+	for _, d := range data {
+		min = math.Min(min, d.Z)
+		max = math.Max(max, d.Z)
+	}
+	if min == max {
+		min -= 0.5
+		max += 0.5
+	}
+	cm.SetMin(min)
+	cm.SetMax(max)
+	return &ColorScatter{
+		XYZs:       data,
+		ColorMap:   cm,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot draws the ColorScatter, implementing the
+// plot.Plotter interface.
+func (pts *ColorScatter) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	sty := pts.GlyphStyle
+	for _, p := range pts.XYZs {
+		sty.Color = pts.color(p.Z)
+		c.DrawGlyph(sty, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	}
+}
+
+// color returns the color of the glyph
+// for the z value.
+func (pts *ColorScatter) color(z float64) color.Color {
+	z = math.Max(pts.ColorMap.Min(), math.Min(pts.ColorMap.Max(), z))
+	col, err := pts.ColorMap.At(z)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger
+// interface.
+func (pts *ColorScatter) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(XYValues{pts.XYZs})
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes,
+// implementing the plot.GlyphBoxer interface.
+func (pts *ColorScatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	r := pts.GlyphStyle.Radius
+	bs := make([]plot.GlyphBox, len(pts.XYZs))
+	for i, p := range pts.XYZs {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -r, Y: -r},
+			Max: vg.Point{X: +r, Y: +r},
+		}
+	}
+	return bs
+}
+
+// Thumbnail draws a glyph in the color of the middle
+// of the ColorMap range, implementing the
+// plot.Thumbnailer interface.
+func (pts *ColorScatter) Thumbnail(c *draw.Canvas) {
+	sty := pts.GlyphStyle
+	sty.Color = pts.color((pts.ColorMap.Min() + pts.ColorMap.Max()) / 2)
+	c.DrawGlyph(sty, c.Center())
+}
@@ -0,0 +1,101 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"os"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// ExampleColorScatter draws points colored by a third
+// variable, with a color bar showing the meaning of
+// the colors.
+func ExampleColorScatter() {
+	data := make(XYZs, 40)
+	for i := range data {
+		theta := float64(i) / 4
+		data[i].X = theta * math.Cos(theta)
+		data[i].Y = theta * math.Sin(theta)
+		data[i].Z = theta
+	}
+
+	cm := moreland.SmoothBlueRed()
+	sc, err := NewColorScatter(data, cm)
+	if err != nil {
+		log.Panic(err)
+	}
+	sc.Shape = draw.CircleGlyph{}
+	sc.Radius = vg.Points(3)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Color Scatter"
+	p.Add(sc)
+
+	bar, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	bar.HideY()
+	bar.X.Label.Text = "θ"
+	bar.Add(&ColorBar{ColorMap: cm})
+
+	img := vgimg.New(200, 250)
+	dc := draw.New(img)
+	p.Draw(draw.Crop(dc, 0, 0, 50, 0))
+	bar.Draw(draw.Crop(dc, 0, 0, 0, -200))
+
+	w, err := os.Create("testdata/colorScatter.png")
+	if err != nil {
+		log.Panic(err)
+	}
+	defer w.Close()
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err = png.WriteTo(w); err != nil {
+		log.Panic(err)
+	}
+	if err = w.Close(); err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestColorScatter(t *testing.T) {
+	cmpimg.CheckPlot(ExampleColorScatter, t, "colorScatter.png")
+}
+
+func TestColorScatterRange(t *testing.T) {
+	cm := moreland.SmoothBlueRed()
+	sc, err := NewColorScatter(XYZs{{X: 0, Y: 1, Z: 5}, {X: 2, Y: 3, Z: -1}}, cm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cm.Min() != -1 || cm.Max() != 5 {
+		t.Errorf("unexpected color map range: got:[%v, %v] want:[-1, 5]", cm.Min(), cm.Max())
+	}
+	xmin, xmax, ymin, ymax := sc.DataRange()
+	if xmin != 0 || xmax != 2 || ymin != 1 || ymax != 3 {
+		t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[0, 2]x[1, 3]", xmin, xmax, ymin, ymax)
+	}
+
+	// Values outside of the color map range take
+	// the color of the end of the range.
+	if got, want := sc.color(10), sc.color(5); got != want {
+		t.Errorf("unexpected color above range: got:%v want:%v", got, want)
+	}
+	if got, want := sc.color(-10), sc.color(-1); got != want {
+		t.Errorf("unexpected color below range: got:%v want:%v", got, want)
+	}
+}
@@ -472,3 +472,45 @@ type testContour []*contour
 func (c testContour) Len() int           { return len(c) }
 func (c testContour) Less(i, j int) bool { return len(c[i].forward) < len(c[j].forward) }
 func (c testContour) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+func TestValueGridContour(t *testing.T) {
+	x := []float64{0, 1, 2, 3}
+	y := []float64{10, 20, 30}
+	values := [][]float64{
+		{0, 0, 0, 0},
+		{0, 2, 2, 0},
+		{0, 0, math.NaN(), 0},
+	}
+	g, err := NewValueGrid(x, y, values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c, r := g.Dims(); c != 4 || r != 3 {
+		t.Errorf("unexpected dimensions: got c=%d r=%d", c, r)
+	}
+	if g.X(3) != 3 || g.Y(2) != 30 || g.Z(1, 1) != 2 {
+		t.Errorf("unexpected grid values: X(3)=%v Y(2)=%v Z(1, 1)=%v", g.X(3), g.Y(2), g.Z(1, 1))
+	}
+
+	ctr := NewContour(g, []float64{1}, palette.Rainbow(1, palette.Blue, palette.Red, 1, 1, 1))
+	if ctr.Min != 0 || ctr.Max != 2 {
+		t.Errorf("unexpected contour range: got min=%v max=%v", ctr.Min, ctr.Max)
+	}
+	xmin, xmax, ymin, ymax := ctr.DataRange()
+	if xmin != 0 || xmax != 3 || ymin != 10 || ymax != 30 {
+		t.Errorf("unexpected data range: got %v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+	paths := contourPaths(g, ctr.Levels, func(v float64) vg.Length { return vg.Length(v) }, func(v float64) vg.Length { return vg.Length(v) })
+	if len(paths[1]) == 0 {
+		t.Error("no contour traced at level 1")
+	}
+
+	_, err = NewValueGrid(x, y, values[:2])
+	if err == nil {
+		t.Error("expected error for mismatched rows")
+	}
+	_, err = NewValueGrid(x[:3], y, values)
+	if err == nil {
+		t.Error("expected error for mismatched columns")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ErrorBand implements the plot.Plotter, plot.DataRanger
+// and plot.Thumbnailer interfaces, drawing a line through
+// a series of points over a shaded band that spans the Y
+// errors of the points.
+type ErrorBand struct {
+	// Line draws the line through the points.
+	*Line
+
+	// Band draws the shaded band.  Its Top and
+	// Bottom are the points offset by their high
+	// and low Y errors.
+	Band *Band
+}
+
+// NewErrorBand returns an ErrorBand for the given points
+// and Y errors, drawn with the default line style over a
+// translucent band of the line's color.  As for
+// NewYErrorBars, the band spans from the absolute value
+// of the low error below each point to the absolute value
+// of the high error above it.
+func NewErrorBand(yerrs interface {
+	XYer
+	YErrorer
+}) (*ErrorBand, error) {
+	data, err := CopyXYs(yerrs)
+	if err != nil {
+		return nil, err
+	}
+	l, err := NewLine(data)
+	if err != nil {
+		return nil, err
+	}
+	top := make(XYs, len(l.XYs))
+	bottom := make(XYs, len(l.XYs))
+	for i, p := range l.XYs {
+		low, high := yerrs.YError(i)
+		if err := CheckFloats(low, high); err != nil {
+			return nil, err
+		}
+		top[i].X, top[i].Y = p.X, p.Y+math.Abs(high)
+		bottom[i].X, bottom[i].Y = p.X, p.Y-math.Abs(low)
+	}
+	b, err := NewBand(top, bottom)
+	if err != nil {
+		return nil, err
+	}
+	fill := color.NRGBAModel.Convert(l.Color).(color.NRGBA)
+	fill.A = 64
+	b.FillColor = fill
+	return &ErrorBand{Line: l, Band: b}, nil
+}
+
+// Plot draws the band beneath the line, implementing
+// the plot.Plotter interface.
+func (e *ErrorBand) Plot(c draw.Canvas, plt *plot.Plot) {
+	e.Band.Plot(c, plt)
+	e.Line.Plot(c, plt)
+}
+
+// DataRange returns the minimum and maximum X and Y
+// values, including the extent of the band,
+// implementing the plot.DataRanger interface.
+func (e *ErrorBand) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = e.Line.DataRange()
+	bxmin, bxmax, bymin, bymax := e.Band.DataRange()
+	return math.Min(xmin, bxmin), math.Max(xmax, bxmax),
+		math.Min(ymin, bymin), math.Max(ymax, bymax)
+}
+
+// Thumbnail draws the line over the band,
+// implementing the plot.Thumbnailer interface.
+func (e *ErrorBand) Thumbnail(c *draw.Canvas) {
+	e.Band.Thumbnail(c)
+	e.Line.Thumbnail(c)
+}
@@ -0,0 +1,76 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+func ExampleErrorBand() {
+	// A noisy measurement whose uncertainty
+	// grows with x.
+	const n = 20
+	pts := make(XYs, n)
+	yerrs := make(YErrors, n)
+	for i := range pts {
+		x := float64(i) / 2
+		pts[i].X, pts[i].Y = x, math.Cos(x)
+		yerrs[i].Low = 0.1 + 0.04*x
+		yerrs[i].High = yerrs[i].Low
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Error Band"
+
+	e, err := NewErrorBand(struct {
+		XYs
+		YErrors
+	}{pts, yerrs})
+	if err != nil {
+		log.Panic(err)
+	}
+	e.Color = color.RGBA{B: 255, A: 255}
+	e.Band.FillColor = color.NRGBA{B: 255, A: 64}
+	p.Add(e)
+	p.Legend.Add("cos", e)
+
+	err = p.Save(200, 200, "testdata/errorBand.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestErrorBand(t *testing.T) {
+	cmpimg.CheckPlot(ExampleErrorBand, t, "errorBand.png")
+}
+
+func TestErrorBandDataRange(t *testing.T) {
+	e, err := NewErrorBand(struct {
+		XYs
+		YErrors
+	}{
+		XYs{{X: 0, Y: 0}, {X: 1, Y: 1}},
+		YErrors{{Low: 1, High: 2}, {Low: -0.5, High: 0.5}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := e.DataRange()
+	if xmin != 0 || xmax != 1 || ymin != -1 || ymax != 2 {
+		t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[0, 1]x[-1, 2]", xmin, xmax, ymin, ymax)
+	}
+	if got := e.Band.Bottom[1].Y; got != 0.5 {
+		t.Errorf("unexpected band bottom for negative low error: got:%v want:0.5", got)
+	}
+}
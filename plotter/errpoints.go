@@ -0,0 +1,104 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ErrorPoints implements the plot.Plotter, plot.DataRanger
+// and plot.GlyphBoxer interfaces, drawing a glyph at each
+// point together with horizontal and vertical error bars.
+type ErrorPoints struct {
+	// Scatter draws the glyphs at the points.
+	*Scatter
+
+	// XBars draws the horizontal error bars.
+	// XBars is nil if the points have no X errors.
+	XBars *XErrorBars
+
+	// YBars draws the vertical error bars.
+	// YBars is nil if the points have no Y errors.
+	YBars *YErrorBars
+}
+
+// NewErrorPoints returns an ErrorPoints for the given points
+// and errors.  Either of xerrs and yerrs may be nil, in which
+// case no error bars are drawn in that direction.  The errors
+// are interpreted as by NewXErrorBars and NewYErrorBars.
+func NewErrorPoints(xys XYer, xerrs XErrorer, yerrs YErrorer) (*ErrorPoints, error) {
+	if xerrs == nil && yerrs == nil {
+		return nil, errors.New("plotter: no errors for error points")
+	}
+	s, err := NewScatter(xys)
+	if err != nil {
+		return nil, err
+	}
+	e := &ErrorPoints{Scatter: s}
+	if xerrs != nil {
+		e.XBars, err = NewXErrorBars(struct {
+			XYer
+			XErrorer
+		}{s.XYs, xerrs})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if yerrs != nil {
+		e.YBars, err = NewYErrorBars(struct {
+			XYer
+			YErrorer
+		}{s.XYs, yerrs})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Plot implements the Plotter interface, drawing
+// the error bars beneath the glyphs.
+func (e *ErrorPoints) Plot(c draw.Canvas, plt *plot.Plot) {
+	if e.XBars != nil {
+		e.XBars.Plot(c, plt)
+	}
+	if e.YBars != nil {
+		e.YBars.Plot(c, plt)
+	}
+	e.Scatter.Plot(c, plt)
+}
+
+// DataRange implements the plot.DataRanger interface,
+// returning a range that includes the error bars.
+func (e *ErrorPoints) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = e.Scatter.DataRange()
+	if e.XBars != nil {
+		x0, x1, _, _ := e.XBars.DataRange()
+		xmin, xmax = math.Min(xmin, x0), math.Max(xmax, x1)
+	}
+	if e.YBars != nil {
+		_, _, y0, y1 := e.YBars.DataRange()
+		ymin, ymax = math.Min(ymin, y0), math.Max(ymax, y1)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// returning the boxes of the glyphs and of the caps at
+// the ends of the error bars.
+func (e *ErrorPoints) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := e.Scatter.GlyphBoxes(plt)
+	if e.XBars != nil {
+		bs = append(bs, e.XBars.GlyphBoxes(plt)...)
+	}
+	if e.YBars != nil {
+		bs = append(bs, e.YBars.GlyphBoxes(plt)...)
+	}
+	return bs
+}
@@ -0,0 +1,89 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleErrorPoints() {
+	rnd := rand.New(rand.NewSource(1))
+
+	// Create measurements with uncertainties in both x and y.
+	const n = 10
+	pts := make(XYs, n)
+	xerrs := make(XErrors, n)
+	yerrs := make(YErrors, n)
+	for i := range pts {
+		pts[i].X = float64(i) + rnd.Float64()*0.5
+		pts[i].Y = 2*pts[i].X + rnd.NormFloat64()
+		xerrs[i].Low = 0.2 + rnd.Float64()*0.2
+		xerrs[i].High = xerrs[i].Low
+		yerrs[i].Low = 0.5 + rnd.Float64()
+		yerrs[i].High = yerrs[i].Low
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Measurements"
+
+	e, err := NewErrorPoints(pts, xerrs, yerrs)
+	if err != nil {
+		log.Panic(err)
+	}
+	e.Radius = vg.Points(2)
+	p.Add(e)
+	p.Legend.Add("data", e)
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	err = p.Save(200, 200, "testdata/errorPoints.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestErrorPoints(t *testing.T) {
+	cmpimg.CheckPlot(ExampleErrorPoints, t, "errorPoints.png")
+}
+
+func TestErrorPointsDataRange(t *testing.T) {
+	pts := XYs{{X: 0, Y: 0}, {X: 1, Y: 1}}
+	yerrs := YErrors{{Low: 1, High: 2}, {Low: 0.5, High: 0.5}}
+
+	e, err := NewErrorPoints(pts, nil, yerrs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.XBars != nil {
+		t.Errorf("unexpected x error bars")
+	}
+	xmin, xmax, ymin, ymax := e.DataRange()
+	if xmin != 0 || xmax != 1 || ymin != -1 || ymax != 2 {
+		t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[0, 1]x[-1, 2]", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(e)
+	if got, want := len(e.GlyphBoxes(p)), len(pts)+2*len(pts); got != want {
+		t.Errorf("unexpected number of glyph boxes: got:%d want:%d", got, want)
+	}
+
+	_, err = NewErrorPoints(pts, nil, nil)
+	if err == nil {
+		t.Errorf("expected error for points without errors")
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"sort"
+	"strconv"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Extremum is a set of kinds of points of a series
+// that are marked by Extrema.  Kinds may be combined
+// with the bitwise or operator.
+type Extremum int
+
+const (
+	// MarkMin marks the point with the minimum Y value.
+	MarkMin Extremum = 1 << iota
+
+	// MarkMax marks the point with the maximum Y value.
+	MarkMax
+
+	// MarkLast marks the last point of the series.
+	MarkLast
+
+	// MarkPeaks marks each point whose Y value is
+	// greater than that of the point before it and
+	// not less than that of the point after it.
+	MarkPeaks
+)
+
+// Extrema implements the Plotter interface, drawing
+// emphasis markers, and optionally labels, at selected
+// points of a series, such as its minimum and maximum.
+// Extrema is intended to be drawn over a plotter of the
+// same series, such as a Line.
+type Extrema struct {
+	// XYs is a copy of the series.
+	XYs
+
+	// Points are the indices in XYs of the
+	// marked points, in increasing order.
+	Points []int
+
+	// GlyphStyle is the style of the markers.
+	draw.GlyphStyle
+
+	// LabelFunc, if not nil, returns the text of
+	// the label that is drawn above each marked
+	// point, given the point's coordinates.
+	LabelFunc func(x, y float64) string
+
+	// TextStyle is the style of the labels.
+	TextStyle draw.TextStyle
+}
+
+// NewExtrema returns an Extrema marking the given kinds
+// of points of the series xys with large red rings.  If
+// there are ties, the first of the tied points is marked
+// as the minimum or maximum.  The labels are not drawn
+// unless LabelFunc is set; FormatY returns a LabelFunc
+// that labels each point with its Y value.
+func NewExtrema(xys XYer, marks Extremum) (*Extrema, error) {
+	if marks == 0 {
+		return nil, errors.New("plotter: no extrema to mark")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Extrema{
+		XYs:    data,
+		Points: extremaOf(data, marks),
+		GlyphStyle: draw.GlyphStyle{
+			Color:  color.RGBA{R: 255, A: 255},
+			Radius: vg.Points(4),
+			Shape:  draw.RingGlyph{},
+		},
+		TextStyle: draw.TextStyle{
+			Font:   fnt,
+			XAlign: draw.XCenter,
+		},
+	}, nil
+}
+
+// FormatY returns a LabelFunc that labels each point
+// with its Y value, formatted as by strconv.FormatFloat
+// with the 'g' format and the given precision.
+func FormatY(prec int) func(x, y float64) string {
+	return func(_, y float64) string {
+		return strconv.FormatFloat(y, 'g', prec, 64)
+	}
+}
+
+// extremaOf returns the sorted indices of the
+// points of xys that are selected by marks.
+func extremaOf(xys XYs, marks Extremum) []int {
+	if len(xys) == 0 {
+		return nil
+	}
+	set := make(map[int]bool)
+	if marks&(MarkMin|MarkMax) != 0 {
+		min, max := 0, 0
+		for i, p := range xys {
+			if p.Y < xys[min].Y {
+				min = i
+			}
+			if p.Y > xys[max].Y {
+				max = i
+			}
+		}
+		if marks&MarkMin != 0 {
+			set[min] = true
+		}
+		if marks&MarkMax != 0 {
+			set[max] = true
+		}
+	}
+	if marks&MarkLast != 0 {
+		set[len(xys)-1] = true
+	}
+	if marks&MarkPeaks != 0 {
+		for i := 1; i < len(xys)-1; i++ {
+			if xys[i].Y > xys[i-1].Y && xys[i].Y >= xys[i+1].Y {
+				set[i] = true
+			}
+		}
+	}
+	idx := make([]int, 0, len(set))
+	for i := range set {
+		idx = append(idx, i)
+	}
+	sort.Ints(idx)
+	return idx
+}
+
+// Plot draws the markers and labels, implementing
+// the plot.Plotter interface.
+func (e *Extrema) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, i := range e.Points {
+		p := e.XYs[i]
+		c.DrawGlyph(e.GlyphStyle, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	}
+	if l := e.labels(); l != nil {
+		l.Plot(c, plt)
+	}
+}
+
+// labels returns the Labels of the marked points,
+// or nil if the points are not labeled.
+func (e *Extrema) labels() *Labels {
+	if e.LabelFunc == nil || len(e.Points) == 0 {
+		return nil
+	}
+	l := &Labels{
+		XYs:       make(XYs, len(e.Points)),
+		Labels:    make([]string, len(e.Points)),
+		TextStyle: make([]draw.TextStyle, len(e.Points)),
+		YOffset:   1.5 * e.Radius,
+	}
+	for j, i := range e.Points {
+		l.XYs[j] = e.XYs[i]
+		l.Labels[j] = e.LabelFunc(e.XYs[i].X, e.XYs[i].Y)
+		l.TextStyle[j] = e.TextStyle
+	}
+	return l
+}
+
+// DataRange returns the minimum and maximum X and Y
+// values of the whole series, implementing the
+// plot.DataRanger interface.
+func (e *Extrema) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(e.XYs)
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes for
+// the markers and labels, implementing the
+// plot.GlyphBoxer interface.
+func (e *Extrema) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(e.Points))
+	for j, i := range e.Points {
+		p := e.XYs[i]
+		bs[j].X = plt.X.Norm(p.X)
+		bs[j].Y = plt.Y.Norm(p.Y)
+		r := e.Radius
+		bs[j].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -r, Y: -r},
+			Max: vg.Point{X: +r, Y: +r},
+		}
+	}
+	if l := e.labels(); l != nil {
+		lbs := l.GlyphBoxes(plt)
+		for k := range lbs {
+			lbs[k].Rectangle.Min.Y += l.YOffset
+			lbs[k].Rectangle.Max.Y += l.YOffset
+		}
+		bs = append(bs, lbs...)
+	}
+	return bs
+}
+
+// Thumbnail draws a marker in the center of the
+// canvas, implementing the plot.Thumbnailer interface.
+func (e *Extrema) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(e.GlyphStyle, c.Center())
+}
@@ -0,0 +1,85 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+func ExampleExtrema() {
+	// A damped oscillation.
+	const n = 40
+	pts := make(XYs, n)
+	for i := range pts {
+		x := float64(i) / 4
+		pts[i].X = x
+		pts[i].Y = math.Exp(-x/5) * math.Sin(x)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Peaks"
+
+	l, err := NewLine(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(l)
+
+	e, err := NewExtrema(pts, MarkMin|MarkPeaks|MarkLast)
+	if err != nil {
+		log.Panic(err)
+	}
+	e.LabelFunc = FormatY(2)
+	p.Add(e)
+
+	err = p.Save(200, 200, "testdata/extrema.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestExtrema(t *testing.T) {
+	cmpimg.CheckPlot(ExampleExtrema, t, "extrema.png")
+}
+
+func TestExtremaPoints(t *testing.T) {
+	pts := XYs{{X: 0, Y: 1}, {X: 1, Y: 3}, {X: 2, Y: 0}, {X: 3, Y: 2}, {X: 4, Y: 2}, {X: 5, Y: 0}, {X: 6, Y: 3}, {X: 7, Y: 1}}
+	for _, test := range []struct {
+		marks Extremum
+		want  []int
+	}{
+		{marks: MarkMin, want: []int{2}},
+		{marks: MarkMax, want: []int{1}},
+		{marks: MarkLast, want: []int{7}},
+		{marks: MarkPeaks, want: []int{1, 3, 6}},
+		{marks: MarkMin | MarkMax | MarkLast, want: []int{1, 2, 7}},
+	} {
+		e, err := NewExtrema(pts, test.marks)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(e.Points, test.want) {
+			t.Errorf("unexpected points for marks %b: got:%v want:%v", test.marks, e.Points, test.want)
+		}
+		xmin, xmax, ymin, ymax := e.DataRange()
+		if xmin != 0 || xmax != 7 || ymin != 0 || ymax != 3 {
+			t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[0, 7]x[0, 3]", xmin, xmax, ymin, ymax)
+		}
+	}
+
+	_, err := NewExtrema(pts, 0)
+	if err == nil {
+		t.Errorf("expected error for no marks")
+	}
+}
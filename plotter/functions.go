@@ -5,6 +5,8 @@
 package plotter
 
 import (
+	"math"
+
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
@@ -25,7 +27,11 @@ type Function struct {
 }
 
 // NewFunction returns a Function that plots F using
-// the default line style with 50 samples.
+// the default line style with 50 samples.  Unless XMin
+// and XMax are set, F is sampled across the X range of
+// the plot each time it is drawn, so the samples follow
+// changes to the range.  The line is broken where F
+// returns NaN or an infinite value.
 func NewFunction(f func(float64) float64) *Function {
 	return &Function{
 		F:         f,
@@ -45,13 +51,24 @@ func (f *Function) Plot(c draw.Canvas, p *plot.Plot) {
 		max = p.X.Max
 	}
 	d := (max - min) / float64(f.Samples-1)
-	line := make([]vg.Point, f.Samples)
-	for i := range line {
+	var lines [][]vg.Point
+	var line []vg.Point
+	for i := 0; i < f.Samples; i++ {
 		x := min + float64(i)*d
-		line[i].X = trX(x)
-		line[i].Y = trY(f.F(x))
+		y := f.F(x)
+		if math.IsNaN(y) || math.IsInf(y, 0) {
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+			line = nil
+			continue
+		}
+		line = append(line, vg.Point{X: trX(x), Y: trY(y)})
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
 	}
-	c.StrokeLines(f.LineStyle, c.ClipLinesXY(line)...)
+	c.StrokeLines(f.LineStyle, c.ClipLinesXY(lines...)...)
 }
 
 // Thumbnail draws a line in the given style down the
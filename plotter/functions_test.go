@@ -13,6 +13,8 @@ import (
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
 )
 
 // ExampleFunction draws some functions.
@@ -59,3 +61,49 @@ func ExampleFunction() {
 func TestFunction(t *testing.T) {
 	cmpimg.CheckPlot(ExampleFunction, t, "functions.png")
 }
+
+func TestFunctionGaps(t *testing.T) {
+	var xs []float64
+	f := NewFunction(func(x float64) float64 {
+		xs = append(xs, x)
+		if x > 4 && x < 6 {
+			return math.NaN()
+		}
+		if x == 8 {
+			return math.Inf(1)
+		}
+		return x
+	})
+	f.Samples = 11
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+
+	var r recorder.Canvas
+	f.Plot(draw.NewCanvas(&r, 100, 100), p)
+	var segments int
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok {
+			for _, comp := range s.Path {
+				if comp.Type == vg.MoveComp {
+					segments++
+				}
+			}
+		}
+	}
+	if segments != 3 {
+		t.Errorf("unexpected number of line segments: got:%d want:3", segments)
+	}
+
+	// The function is resampled across a changed range.
+	xs = xs[:0]
+	p.X.Min, p.X.Max = 20, 30
+	f.Plot(draw.NewCanvas(&r, 100, 100), p)
+	if len(xs) != 11 || xs[0] != 20 || xs[10] != 30 {
+		t.Errorf("unexpected samples after range change: got:%v", xs)
+	}
+}
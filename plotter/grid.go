@@ -18,16 +18,31 @@ var (
 		Color: color.Gray{128},
 		Width: vg.Points(0.25),
 	}
+
+	// DefaultMinorGridLineStyle is the default style for
+	// minor grid lines on log-scale axes.
+	DefaultMinorGridLineStyle = draw.LineStyle{
+		Color: color.Gray{200},
+		Width: vg.Points(0.25),
+	}
 )
 
 // Grid implements the plot.Plotter interface, drawing
-// a set of grid lines at the major tick marks.
+// a set of grid lines at the major tick marks, and
+// optionally at the sub-decade positions of log-scale axes.
 type Grid struct {
 	// Vertical is the style of the vertical lines.
 	Vertical draw.LineStyle
 
 	// Horizontal is the style of the horizontal lines.
 	Horizontal draw.LineStyle
+
+	// MinorVertical and MinorHorizontal are the styles
+	// of the lines drawn at 2, 3, ..., 9 times each
+	// decade when the corresponding axis uses a
+	// plot.LogScale.  Minor lines are not drawn for
+	// other scales, or if the style's Color is nil.
+	MinorVertical, MinorHorizontal draw.LineStyle
 }
 
 // NewGrid returns a new grid with both vertical and
@@ -39,10 +54,44 @@ func NewGrid() *Grid {
 	}
 }
 
+// NewLogGrid returns a new grid like NewGrid that
+// also draws minor lines within each decade of
+// log-scale axes using the default minor grid
+// line style.
+func NewLogGrid() *Grid {
+	return &Grid{
+		Vertical:        DefaultGridLineStyle,
+		Horizontal:      DefaultGridLineStyle,
+		MinorVertical:   DefaultMinorGridLineStyle,
+		MinorHorizontal: DefaultMinorGridLineStyle,
+	}
+}
+
 // Plot implements the plot.Plotter interface.
 func (g *Grid) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
 
+	// Minor lines are drawn first so that the major
+	// lines are drawn over them.
+	if g.MinorVertical.Color != nil {
+		for _, v := range logMinorValues(plt.X) {
+			x := trX(v)
+			if !c.ContainsX(x) {
+				continue
+			}
+			c.StrokeLine2(g.MinorVertical, x, c.Min.Y, x, c.Max.Y)
+		}
+	}
+	if g.MinorHorizontal.Color != nil {
+		for _, v := range logMinorValues(plt.Y) {
+			y := trY(v)
+			if !c.ContainsY(y) {
+				continue
+			}
+			c.StrokeLine2(g.MinorHorizontal, c.Min.X, y, c.Max.X, y)
+		}
+	}
+
 	var (
 		ymin = c.Min.Y
 		ymax = c.Max.Y
@@ -79,3 +128,81 @@ horiz:
 		c.StrokeLine2(g.Horizontal, xmin, y, xmax, y)
 	}
 }
+
+// logMinorValues returns the sub-decade tick values
+// of a log-scale axis.  It returns nil if the axis
+// does not use a plot.LogScale or its range is not
+// strictly positive.
+func logMinorValues(a plot.Axis) []float64 {
+	if _, ok := a.Scale.(plot.LogScale); !ok || a.Min <= 0 {
+		return nil
+	}
+	var (
+		vs     []float64
+		decade float64
+	)
+	for _, tk := range (plot.LogTicks{}).Ticks(a.Min, a.Max) {
+		if !tk.IsMinor() {
+			decade = tk.Value
+			continue
+		}
+		if tk.Value != decade {
+			vs = append(vs, tk.Value)
+		}
+	}
+	return vs
+}
+
+// FixedGrid implements the plot.Plotter interface, drawing
+// grid lines at fixed data values, independent of the
+// axes' tick marks.
+type FixedGrid struct {
+	// X and Y are the data values at which vertical and
+	// horizontal lines are drawn respectively.  Values
+	// outside the range of the corresponding axis are
+	// not drawn.
+	X, Y []float64
+
+	// Vertical is the style of the vertical lines.
+	Vertical draw.LineStyle
+
+	// Horizontal is the style of the horizontal lines.
+	Horizontal draw.LineStyle
+}
+
+// NewFixedGrid returns a new grid with vertical lines
+// at the x values and horizontal lines at the y values,
+// using the default grid line style.
+func NewFixedGrid(x, y []float64) *FixedGrid {
+	return &FixedGrid{
+		X:          append([]float64(nil), x...),
+		Y:          append([]float64(nil), y...),
+		Vertical:   DefaultGridLineStyle,
+		Horizontal: DefaultGridLineStyle,
+	}
+}
+
+// Plot implements the plot.Plotter interface.
+func (g *FixedGrid) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	if g.Vertical.Color != nil {
+		for _, v := range g.X {
+			x := trX(v)
+			if !c.ContainsX(x) {
+				continue
+			}
+			c.StrokeLine2(g.Vertical, x, c.Min.Y, x, c.Max.Y)
+		}
+	}
+
+	if g.Horizontal.Color != nil {
+		for _, v := range g.Y {
+			y := trY(v)
+			if !c.ContainsY(y) {
+				continue
+			}
+			c.StrokeLine2(g.Horizontal, c.Min.X, y, c.Max.X, y)
+		}
+	}
+}
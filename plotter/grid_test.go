@@ -0,0 +1,86 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
+)
+
+func TestFixedGrid(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 10
+
+	g := NewFixedGrid([]float64{-1, 2, 5}, []float64{5, 11})
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 100, 100)
+	g.Plot(c, p)
+
+	var got []vg.Path
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.Stroke); ok {
+			got = append(got, s.Path)
+		}
+	}
+	want := []vg.Path{
+		{{Type: vg.MoveComp, Pos: vg.Point{X: 20, Y: 0}}, {Type: vg.LineComp, Pos: vg.Point{X: 20, Y: 100}}},
+		{{Type: vg.MoveComp, Pos: vg.Point{X: 50, Y: 0}}, {Type: vg.LineComp, Pos: vg.Point{X: 50, Y: 100}}},
+		{{Type: vg.MoveComp, Pos: vg.Point{X: 0, Y: 50}}, {Type: vg.LineComp, Pos: vg.Point{X: 100, Y: 50}}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of grid lines: got:%d want:%d", len(got), len(want))
+	}
+	for i := range want {
+		for j := range want[i] {
+			if got[i][j].Type != want[i][j].Type || got[i][j].Pos != want[i][j].Pos {
+				t.Errorf("unexpected grid line %d: got:%v want:%v", i, got[i], want[i])
+				break
+			}
+		}
+	}
+}
+
+func TestGridLogMinor(t *testing.T) {
+	for _, test := range []struct {
+		scale plot.Normalizer
+		want  int
+	}{
+		{scale: plot.LinearScale{}, want: 3},
+		{scale: plot.LogScale{}, want: 3 + 8*2},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.X.Min, p.X.Max = 1, 100
+		p.X.Scale = test.scale
+		p.X.Tick.Marker = plot.LogTicks{}
+
+		g := NewLogGrid()
+		g.Horizontal.Color = nil
+		g.MinorHorizontal.Color = nil
+		var r recorder.Canvas
+		c := draw.NewCanvas(&r, 100, 100)
+		g.Plot(c, p)
+
+		var got int
+		for _, a := range r.Actions {
+			if _, ok := a.(*recorder.Stroke); ok {
+				got++
+			}
+		}
+		if got != test.want {
+			t.Errorf("unexpected number of grid lines for %T: got:%d want:%d", test.scale, got, test.want)
+		}
+	}
+}
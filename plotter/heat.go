@@ -5,6 +5,7 @@
 package plotter
 
 import (
+	"fmt"
 	"image/color"
 	"math"
 
@@ -33,6 +34,87 @@ type GridXYZ interface {
 	Y(r int) float64
 }
 
+// ValueGrid is a GridXYZ holding the values of a
+// scalar field sampled on a rectangular grid, with
+// the coordinates of the grid given as vectors.
+type ValueGrid struct {
+	// Xs and Ys are the coordinates of the
+	// columns and rows of the grid.
+	Xs, Ys []float64
+
+	// Values holds the grid values, indexed
+	// by row and then by column.
+	Values [][]float64
+}
+
+// NewValueGrid returns a ValueGrid with copies of the
+// given column and row coordinates and values, where
+// values is indexed by row and then by column.  An
+// error is returned if the dimensions of values do
+// not match the lengths of x and y.
+func NewValueGrid(x, y []float64, values [][]float64) (*ValueGrid, error) {
+	if len(x) == 0 || len(y) == 0 {
+		return nil, ErrNoData
+	}
+	if len(values) != len(y) {
+		return nil, fmt.Errorf("plotter: number of rows %d does not match number of y coordinates %d", len(values), len(y))
+	}
+	v := make([][]float64, len(values))
+	for i, row := range values {
+		if len(row) != len(x) {
+			return nil, fmt.Errorf("plotter: length of row %d is %d, want %d", i, len(row), len(x))
+		}
+		v[i] = append([]float64(nil), row...)
+	}
+	return &ValueGrid{
+		Xs:     append([]float64(nil), x...),
+		Ys:     append([]float64(nil), y...),
+		Values: v,
+	}, nil
+}
+
+// Dims implements the Dims method of the GridXYZ interface.
+func (g *ValueGrid) Dims() (c, r int) { return len(g.Xs), len(g.Ys) }
+
+// Z implements the Z method of the GridXYZ interface.
+func (g *ValueGrid) Z(c, r int) float64 { return g.Values[r][c] }
+
+// X implements the X method of the GridXYZ interface.
+func (g *ValueGrid) X(c int) float64 { return g.Xs[c] }
+
+// Y implements the Y method of the GridXYZ interface.
+func (g *ValueGrid) Y(r int) float64 { return g.Ys[r] }
+
+// Min returns the minimum value of the grid, ignoring NaN
+// values.  It is used by NewHeatMap and NewContour to set
+// the dynamic range of the plotter.
+func (g *ValueGrid) Min() float64 {
+	min := math.Inf(1)
+	for _, row := range g.Values {
+		for _, v := range row {
+			if v < min {
+				min = v
+			}
+		}
+	}
+	return min
+}
+
+// Max returns the maximum value of the grid, ignoring NaN
+// values.  It is used by NewHeatMap and NewContour to set
+// the dynamic range of the plotter.
+func (g *ValueGrid) Max() float64 {
+	max := math.Inf(-1)
+	for _, row := range g.Values {
+		for _, v := range row {
+			if v > max {
+				max = v
+			}
+		}
+	}
+	return max
+}
+
 // HeatMap implements the Plotter interface, drawing
 // a heat map of the values in the GridXYZ field.
 type HeatMap struct {
@@ -40,9 +122,16 @@ type HeatMap struct {
 
 	// Palette is the color palette used to render
 	// the heat map. Palette must not be nil or
-	// return a zero length []color.Color.
+	// return a zero length []color.Color unless
+	// ColorMap is not nil.
 	Palette palette.Palette
 
+	// ColorMap, if not nil, is used instead of
+	// Palette to map the values within the
+	// dynamic range to colors.  Values that the
+	// ColorMap can not map are filled with NaN.
+	ColorMap palette.ColorMap
+
 	// Underflow and Overflow are colors used to fill
 	// heat map elements outside the dynamic range
 	// defined by Min and Max.
@@ -96,52 +185,55 @@ func NewHeatMap(g GridXYZ, p palette.Palette) *HeatMap {
 	}
 }
 
+// NewHeatMapColorMap creates a new heat map plotter for the
+// given data, using the provided ColorMap.  The dynamic range
+// of the heat map is set to the range of the ColorMap, so the
+// heat map may be paired with a ColorBar for the same ColorMap.
+// Values spanning orders of magnitude may be mapped by their
+// logarithm with a ColorMap returned by palette.Log, in which
+// case non-positive values lie below the dynamic range and are
+// filled with Underflow.
+func NewHeatMapColorMap(g GridXYZ, cm palette.ColorMap) *HeatMap {
+	return &HeatMap{
+		GridXYZ:  g,
+		ColorMap: cm,
+		Min:      cm.Min(),
+		Max:      cm.Max(),
+	}
+}
+
 // Plot implements the Plot method of the plot.Plotter interface.
 func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 	if h.Min > h.Max {
 		panic("contour: invalid Z range: min greater than max")
 	}
-	pal := h.Palette.Colors()
-	if len(pal) == 0 {
-		panic("heatmap: empty palette")
+	var (
+		pal []color.Color
+		ps  float64
+	)
+	if h.ColorMap == nil {
+		pal = h.Palette.Colors()
+		if len(pal) == 0 {
+			panic("heatmap: empty palette")
+		}
+		// ps scales the palette uniformly across the data range.
+		ps = float64(len(pal)-1) / (h.Max - h.Min)
 	}
-	// ps scales the palette uniformly across the data range.
-	ps := float64(len(pal)-1) / (h.Max - h.Min)
 
 	trX, trY := plt.Transforms(&c)
 
-	var pa vg.Path
+	// The cell edges are transformed once so that
+	// adjacent cells share exactly the same edge and
+	// tile without gaps.
 	cols, rows := h.GridXYZ.Dims()
-	for i := 0; i < cols; i++ {
-		var right, left float64
-		switch i {
-		case 0:
-			right = (h.GridXYZ.X(1) - h.GridXYZ.X(0)) / 2
-			left = -right
-		case cols - 1:
-			right = (h.GridXYZ.X(cols-1) - h.GridXYZ.X(cols-2)) / 2
-			left = -right
-		default:
-			right = (h.GridXYZ.X(i+1) - h.GridXYZ.X(i)) / 2
-			left = -(h.GridXYZ.X(i) - h.GridXYZ.X(i-1)) / 2
-		}
+	xs := cellEdges(cols, h.GridXYZ.X, trX)
+	ys := cellEdges(rows, h.GridXYZ.Y, trY)
 
+	var pa vg.Path
+	for i := 0; i < cols; i++ {
 		for j := 0; j < rows; j++ {
-			var up, down float64
-			switch j {
-			case 0:
-				up = (h.GridXYZ.Y(1) - h.GridXYZ.Y(0)) / 2
-				down = -up
-			case rows - 1:
-				up = (h.GridXYZ.Y(rows-1) - h.GridXYZ.Y(rows-2)) / 2
-				down = -up
-			default:
-				up = (h.GridXYZ.Y(j+1) - h.GridXYZ.Y(j)) / 2
-				down = -(h.GridXYZ.Y(j) - h.GridXYZ.Y(j-1)) / 2
-			}
-
-			x, y := trX(h.GridXYZ.X(i)+left), trY(h.GridXYZ.Y(j)+down)
-			dx, dy := trX(h.GridXYZ.X(i)+right), trY(h.GridXYZ.Y(j)+up)
+			x, y := xs[i], ys[j]
+			dx, dy := xs[i+1], ys[j+1]
 
 			if !c.Contains(vg.Point{X: x, Y: y}) || !c.Contains(vg.Point{X: dx, Y: dy}) {
 				continue
@@ -160,8 +252,14 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 				col = h.Underflow
 			case v > h.Max:
 				col = h.Overflow
-			case math.IsNaN(v), math.IsInf(ps, 0):
+			case math.IsNaN(v), h.ColorMap == nil && math.IsInf(ps, 0):
 				col = h.NaN
+			case h.ColorMap != nil:
+				var err error
+				col, err = h.ColorMap.At(v)
+				if err != nil {
+					col = h.NaN
+				}
 			default:
 				col = pal[int((v-h.Min)*ps+0.5)] // Apply palette scaling.
 			}
@@ -173,6 +271,26 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 	}
 }
 
+// cellEdges returns the transformed positions of the n+1
+// edges of n grid cells centered on the coordinates returned
+// by coord.  Interior edges lie midway between neighboring
+// coordinates, and the outer cells are symmetric about their
+// coordinates.  A single cell is given unit width.
+func cellEdges(n int, coord func(int) float64, tr func(float64) vg.Length) []vg.Length {
+	edges := make([]vg.Length, n+1)
+	if n == 1 {
+		edges[0] = tr(coord(0) - 0.5)
+		edges[1] = tr(coord(0) + 0.5)
+		return edges
+	}
+	edges[0] = tr(coord(0) - (coord(1)-coord(0))/2)
+	for i := 1; i < n; i++ {
+		edges[i] = tr(coord(i-1) + (coord(i)-coord(i-1))/2)
+	}
+	edges[n] = tr(coord(n-1) + (coord(n-1)-coord(n-2))/2)
+	return edges
+}
+
 // DataRange implements the DataRange method
 // of the plot.DataRanger interface.
 func (h *HeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
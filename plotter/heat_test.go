@@ -6,6 +6,7 @@ package plotter
 
 import (
 	"fmt"
+	"image/color"
 	"log"
 	"math"
 	"os"
@@ -15,8 +16,10 @@ import (
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/moreland"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/recorder"
 	"gonum.org/v1/plot/vg/vgimg"
 )
 
@@ -128,3 +131,118 @@ func ExampleHeatMap() {
 func TestHeatMap(t *testing.T) {
 	cmpimg.CheckPlot(ExampleHeatMap, t, "heatMap.png")
 }
+
+func TestHeatMapColorMap(t *testing.T) {
+	g, err := NewValueGrid(
+		[]float64{0, 0.1, 0.3, 0.7},
+		[]float64{0, 1},
+		[][]float64{
+			{0, 1, 2, 3},
+			{4, 5, 6, math.NaN()},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cm := moreland.SmoothBlueRed()
+	cm.SetMin(0)
+	cm.SetMax(5)
+	h := NewHeatMapColorMap(g, cm)
+	if h.Min != 0 || h.Max != 5 {
+		t.Errorf("unexpected dynamic range: got min=%v max=%v", h.Min, h.Max)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = -1, 1
+	p.Y.Min, p.Y.Max = -1, 2
+	var r recorder.Canvas
+	h.Plot(draw.NewCanvas(&r, 1000, 1000), p)
+
+	var (
+		fills  []vg.Path
+		colors []color.Color
+		cur    color.Color
+	)
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.SetColor:
+			cur = a.Color
+		case *recorder.Fill:
+			fills = append(fills, a.Path)
+			colors = append(colors, cur)
+		}
+	}
+	// The value 6 overflows and NaN has no color,
+	// so neither cell is filled.
+	if len(fills) != 6 {
+		t.Fatalf("unexpected number of filled cells: got:%d want:6", len(fills))
+	}
+	want, _ := cm.At(2)
+	if colors[4] != want {
+		t.Errorf("unexpected cell color: got:%v want:%v", colors[4], want)
+	}
+	// Cells are filled in column major order; the
+	// right edge of each cell in the first row must
+	// be the left edge of the next.
+	firstRow := []int{0, 2, 4, 5}
+	for i := 0; i < 3; i++ {
+		right := fills[firstRow[i]][1].Pos.X
+		left := fills[firstRow[i+1]][0].Pos.X
+		if right != left {
+			t.Errorf("gap between cells %d and %d: right edge %v left edge %v", i, i+1, right, left)
+		}
+	}
+}
+
+func TestHeatMapLogColorMap(t *testing.T) {
+	g, err := NewValueGrid(
+		[]float64{0, 1, 2, 3},
+		[]float64{0},
+		[][]float64{{-1, 1, 10, 100}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lin, err := moreland.NewLuminance([]color.Color{color.Black, color.White})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cm := palette.Log(lin)
+	cm.SetMin(1)
+	cm.SetMax(100)
+	h := NewHeatMapColorMap(g, cm)
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = -1, 4
+	p.Y.Min, p.Y.Max = -1, 1
+	var r recorder.Canvas
+	h.Plot(draw.NewCanvas(&r, 1000, 1000), p)
+
+	var colors []color.Color
+	for _, a := range r.Actions {
+		if sc, ok := a.(*recorder.SetColor); ok {
+			colors = append(colors, sc.Color)
+		}
+	}
+	// The non-positive value underflows, and the
+	// decade values are spread evenly across the
+	// underlying linear ColorMap.
+	if len(colors) != 3 {
+		t.Fatalf("unexpected number of filled cells: got:%d want:3", len(colors))
+	}
+	for i, v := range []float64{1, 50.5, 100} {
+		want, err := lin.At(v)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if colors[i] != want {
+			t.Errorf("unexpected color for cell %d: got:%v want:%v", i+1, colors[i], want)
+		}
+	}
+}
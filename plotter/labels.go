@@ -36,6 +36,20 @@ type Labels struct {
 	// XOffset and YOffset are added directly to the final
 	// label X and Y location respectively.
 	XOffset, YOffset vg.Length
+
+	// AvoidOverlap specifies that, when the labels are
+	// drawn, overlapping labels are nudged apart so that
+	// they overlap each other as little as possible while
+	// staying near their points and within the data area.
+	// The space needed by moved labels is not included in
+	// the GlyphBoxes of the Labels.
+	AvoidOverlap bool
+
+	// LeaderStyle is the style of the leader lines drawn
+	// from each label moved by AvoidOverlap to its point.
+	// If LeaderStyle.Width is zero, which is the default,
+	// no leader lines are drawn.
+	LeaderStyle draw.LineStyle
 }
 
 // NewLabels returns a new Labels using the DefaultFont and
@@ -75,14 +89,129 @@ func NewLabels(d XYLabeller) (*Labels, error) {
 // Plot implements the Plotter interface, drawing labels.
 func (l *Labels) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
+	var (
+		idx     []int
+		anchors []vg.Point
+		pts     []vg.Point
+		rects   []vg.Rectangle
+	)
 	for i, label := range l.Labels {
 		pt := vg.Point{X: trX(l.XYs[i].X), Y: trY(l.XYs[i].Y)}
 		if !c.Contains(pt) {
 			continue
 		}
+		idx = append(idx, i)
+		anchors = append(anchors, pt)
 		pt.X += l.XOffset
 		pt.Y += l.YOffset
-		c.FillText(l.TextStyle[i], pt, label)
+		pts = append(pts, pt)
+		rects = append(rects, l.TextStyle[i].Rectangle(label))
+	}
+
+	if !l.AvoidOverlap {
+		for k, i := range idx {
+			c.FillText(l.TextStyle[i], pts[k], l.Labels[i])
+		}
+		return
+	}
+
+	placed := placeLabels(pts, rects, c.Rectangle)
+	if l.LeaderStyle.Width > 0 {
+		var leaders [][]vg.Point
+		for k, pt := range placed {
+			if pt == pts[k] {
+				continue
+			}
+			r := translate(rects[k], pt)
+			end := nearestPoint(r, anchors[k])
+			if end == anchors[k] {
+				continue
+			}
+			leaders = append(leaders, []vg.Point{anchors[k], end})
+		}
+		c.StrokeLines(l.LeaderStyle, leaders...)
+	}
+	for k, i := range idx {
+		c.FillText(l.TextStyle[i], placed[k], l.Labels[i])
+	}
+}
+
+// placeLabels returns the positions of labels nudged
+// apart from the positions pts so that their rectangles,
+// given relative to the positions, overlap as little as
+// possible.  Each overlapping pair of labels is pushed
+// apart along the direction in which they overlap least,
+// and labels are kept within bounds where they fit,
+// repeating until no labels overlap or an iteration
+// limit is reached.
+func placeLabels(pts []vg.Point, rects []vg.Rectangle, bounds vg.Rectangle) []vg.Point {
+	const maxIter = 200
+
+	placed := append([]vg.Point(nil), pts...)
+	for iter := 0; iter < maxIter; iter++ {
+		moved := false
+		for i := range placed {
+			for j := i + 1; j < len(placed); j++ {
+				a := translate(rects[i], placed[i])
+				b := translate(rects[j], placed[j])
+				dx := minLength(a.Max.X, b.Max.X) - maxLength(a.Min.X, b.Min.X)
+				dy := minLength(a.Max.Y, b.Max.Y) - maxLength(a.Min.Y, b.Min.Y)
+				if dx <= 0 || dy <= 0 {
+					continue
+				}
+				moved = true
+				if dx < dy {
+					d := dx / 2
+					if a.Min.X+a.Max.X > b.Min.X+b.Max.X {
+						d = -d
+					}
+					placed[i].X -= d
+					placed[j].X += d
+				} else {
+					d := dy / 2
+					if a.Min.Y+a.Max.Y > b.Min.Y+b.Max.Y {
+						d = -d
+					}
+					placed[i].Y -= d
+					placed[j].Y += d
+				}
+			}
+		}
+		for i, pt := range placed {
+			r := translate(rects[i], pt)
+			switch {
+			case r.Max.X-r.Min.X > bounds.Max.X-bounds.Min.X:
+			case r.Min.X < bounds.Min.X:
+				placed[i].X += bounds.Min.X - r.Min.X
+			case r.Max.X > bounds.Max.X:
+				placed[i].X -= r.Max.X - bounds.Max.X
+			}
+			switch {
+			case r.Max.Y-r.Min.Y > bounds.Max.Y-bounds.Min.Y:
+			case r.Min.Y < bounds.Min.Y:
+				placed[i].Y += bounds.Min.Y - r.Min.Y
+			case r.Max.Y > bounds.Max.Y:
+				placed[i].Y -= r.Max.Y - bounds.Max.Y
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+	return placed
+}
+
+// translate returns the rectangle r moved by p.
+func translate(r vg.Rectangle, p vg.Point) vg.Rectangle {
+	return vg.Rectangle{Min: r.Min.Add(p), Max: r.Max.Add(p)}
+}
+
+// nearestPoint returns the point of the
+// rectangle r that is nearest to p.
+func nearestPoint(r vg.Rectangle, p vg.Point) vg.Point {
+	return vg.Point{
+		X: maxLength(r.Min.X, minLength(p.X, r.Max.X)),
+		Y: maxLength(r.Min.Y, minLength(p.Y, r.Max.Y)),
 	}
 }
 
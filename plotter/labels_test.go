@@ -0,0 +1,86 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleLabels_avoidOverlap() {
+	// Clustered points whose labels would
+	// overlap if drawn at the points.
+	pts := XYLabels{
+		XYs: XYs{
+			{X: 1, Y: 1}, {X: 1.1, Y: 1.05}, {X: 1.2, Y: 0.95}, {X: 1.15, Y: 1.1},
+			{X: 3, Y: 3}, {X: 3.05, Y: 3.02}, {X: 4, Y: 2},
+		},
+		Labels: []string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta", "eta"},
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Labels Avoiding Overlap"
+	p.X.Min, p.X.Max = 0, 5
+	p.Y.Min, p.Y.Max = 0, 4
+
+	s, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	l, err := NewLabels(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.AvoidOverlap = true
+	l.LeaderStyle = DefaultLineStyle
+	l.LeaderStyle.Width = vg.Points(0.5)
+	p.Add(s, l)
+
+	err = p.Save(200, 200, "testdata/labelsAvoidOverlap.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLabelsAvoidOverlap(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLabels_avoidOverlap, t, "labelsAvoidOverlap.png")
+}
+
+func TestPlaceLabels(t *testing.T) {
+	rect := vg.Rectangle{Max: vg.Point{X: 20, Y: 10}}
+	rects := []vg.Rectangle{rect, rect, rect, rect}
+	pts := []vg.Point{{X: 50, Y: 50}, {X: 55, Y: 52}, {X: 50, Y: 50}, {X: 95, Y: 95}}
+	bounds := vg.Rectangle{Max: vg.Point{X: 100, Y: 100}}
+
+	placed := placeLabels(pts, rects, bounds)
+	for i := range placed {
+		a := translate(rects[i], placed[i])
+		if a.Min.X < bounds.Min.X || a.Min.Y < bounds.Min.Y || a.Max.X > bounds.Max.X || a.Max.Y > bounds.Max.Y {
+			t.Errorf("label %d outside bounds: %+v", i, a)
+		}
+		for j := i + 1; j < len(placed); j++ {
+			b := translate(rects[j], placed[j])
+			if a.Min.X < b.Max.X && b.Min.X < a.Max.X && a.Min.Y < b.Max.Y && b.Min.Y < a.Max.Y {
+				t.Errorf("labels %d and %d overlap: %+v %+v", i, j, a, b)
+			}
+		}
+	}
+
+	// Labels that do not overlap are not moved.
+	pts = []vg.Point{{X: 10, Y: 10}, {X: 50, Y: 50}}
+	placed = placeLabels(pts, rects[:2], bounds)
+	for i := range pts {
+		if placed[i] != pts[i] {
+			t.Errorf("unexpected position of label %d: got:%+v want:%+v", i, placed[i], pts[i])
+		}
+	}
+}
@@ -6,29 +6,59 @@ package plotter
 
 import (
 	"image/color"
+	"math"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
 
+// StepKind specifies a form of a connection of two consecutive points.
+type StepKind int
+
+const (
+	// NoStep connects two points by simple line.
+	NoStep StepKind = iota
+
+	// PreStep connects two points by following lines: vertical, horizontal.
+	PreStep
+
+	// MidStep connects two points by following lines: horizontal, vertical, horizontal.
+	// Vertical line is placed in the middle of the interval.
+	MidStep
+
+	// PostStep connects two points by following lines: horizontal, vertical.
+	PostStep
+)
+
 // Line implements the Plotter interface, drawing a line.
 type Line struct {
 	// XYs is a copy of the points for this line.
 	XYs
 
+	// StepStyle is the kind of the step line.
+	StepStyle StepKind
+
 	// LineStyle is the style of the line connecting
 	// the points.
 	draw.LineStyle
 
 	// ShadeColor is the color of the shaded area.
 	ShadeColor *color.Color
+
+	// SortedX specifies that the points are sorted by
+	// increasing x value. When SortedX is true, points
+	// outside the x range of the plot are skipped when
+	// drawing, which keeps drawing a narrow window of
+	// a large data set fast.
+	SortedX bool
 }
 
 // NewLine returns a Line that uses the default line style and
-// does not draw glyphs.
+// does not draw glyphs.  Points with a NaN y value are gaps in
+// the line; the line is broken at each gap.
 func NewLine(xys XYer) (*Line, error) {
-	data, err := CopyXYs(xys)
+	data, err := copyGappedXYs(xys)
 	if err != nil {
 		return nil, err
 	}
@@ -42,34 +72,117 @@ func NewLine(xys XYer) (*Line, error) {
 // interface.
 func (pts *Line) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
-	ps := make([]vg.Point, len(pts.XYs))
+	xys := pts.XYs
+	if pts.SortedX {
+		lo, hi := xys.XWindow(plt.X.Min, plt.X.Max)
+		xys = xys[lo:hi]
+	}
+	for _, seg := range segments(xys) {
+		ps := make([]vg.Point, len(seg))
+		for i, p := range seg {
+			ps[i].X = trX(p.X)
+			ps[i].Y = trY(p.Y)
+		}
+		ps = pts.StepStyle.steps(ps)
 
-	for i, p := range pts.XYs {
-		ps[i].X = trX(p.X)
-		ps[i].Y = trY(p.Y)
+		if pts.ShadeColor != nil {
+			c.SetColor(*pts.ShadeColor)
+			minY := trY(plt.Y.Min)
+			var pa vg.Path
+			pa.Move(vg.Point{X: ps[0].X, Y: minY})
+			for i := range ps {
+				pa.Line(ps[i])
+			}
+			pa.Line(vg.Point{X: ps[len(ps)-1].X, Y: minY})
+			pa.Close()
+			c.Fill(pa)
+		}
+
+		c.StrokeLines(pts.LineStyle, c.ClipLinesXY(ps)...)
+	}
+}
+
+// copyGappedXYs returns a copy of the x and y values
+// from an XYer, or an error if one of the x values is
+// NaN or Infinity or one of the y values is Infinity.
+func copyGappedXYs(data XYer) (XYs, error) {
+	cpy := make(XYs, data.Len())
+	for i := range cpy {
+		cpy[i].X, cpy[i].Y = data.XY(i)
+		if math.IsNaN(cpy[i].Y) {
+			if err := CheckFloats(cpy[i].X); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := CheckFloats(cpy[i].X, cpy[i].Y); err != nil {
+			return nil, err
+		}
 	}
+	return cpy, nil
+}
 
-	if pts.ShadeColor != nil && len(ps) > 0 {
-		c.SetColor(*pts.ShadeColor)
-		minY := trY(plt.Y.Min)
-		var pa vg.Path
-		pa.Move(vg.Point{X: ps[0].X, Y: minY})
-		for i := range pts.XYs {
-			pa.Line(ps[i])
+// segments returns the runs of points in xys that
+// are separated by points with a NaN y value.
+func segments(xys XYs) []XYs {
+	var segs []XYs
+	start := 0
+	for i, p := range xys {
+		if math.IsNaN(p.Y) {
+			if i > start {
+				segs = append(segs, xys[start:i])
+			}
+			start = i + 1
 		}
-		pa.Line(vg.Point{X: ps[len(pts.XYs)-1].X, Y: minY})
-		pa.Close()
-		c.Fill(pa)
 	}
+	if start < len(xys) {
+		segs = append(segs, xys[start:])
+	}
+	return segs
+}
 
-	c.StrokeLines(pts.LineStyle, c.ClipLinesXY(ps)...)
+// steps returns the vertices of the line connecting the
+// points in ps with the step kind.
+func (k StepKind) steps(ps []vg.Point) []vg.Point {
+	if k == NoStep || len(ps) < 2 {
+		return ps
+	}
+	n := 2*len(ps) - 1
+	if k == MidStep {
+		n = 3*len(ps) - 2
+	}
+	stepped := make([]vg.Point, 0, n)
+	stepped = append(stepped, ps[0])
+	for i, p := range ps[1:] {
+		prev := ps[i]
+		switch k {
+		case PreStep:
+			stepped = append(stepped, vg.Point{X: prev.X, Y: p.Y})
+		case MidStep:
+			mid := (prev.X + p.X) / 2
+			stepped = append(stepped, vg.Point{X: mid, Y: prev.Y}, vg.Point{X: mid, Y: p.Y})
+		case PostStep:
+			stepped = append(stepped, vg.Point{X: p.X, Y: prev.Y})
+		default:
+			panic("plotter: unknown StepKind")
+		}
+		stepped = append(stepped, p)
+	}
+	return stepped
 }
 
 // DataRange returns the minimum and maximum
 // x and y values, implementing the plot.DataRanger
-// interface.
+// interface.  Points with a NaN y value are ignored.
 func (pts *Line) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return XYRange(pts)
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, seg := range segments(pts.XYs) {
+		x0, x1, y0, y1 := XYRange(seg)
+		xmin, xmax = math.Min(xmin, x0), math.Max(xmax, x1)
+		ymin, ymax = math.Min(ymin, y0), math.Max(ymax, y1)
+	}
+	return xmin, xmax, ymin, ymax
 }
 
 // Thumbnail the thumbnail for the Line,
@@ -105,3 +218,79 @@ func NewLinePoints(xys XYer) (*Line, *Scatter, error) {
 	}
 	return l, s, nil
 }
+
+// MarkedLine implements the Plotter interface, drawing
+// a line connecting a set of points and a glyph at
+// each of the points.
+type MarkedLine struct {
+	// XYs is a copy of the points for this line.
+	XYs
+
+	// LineStyle is the style of the line connecting
+	// the points.
+	LineStyle draw.LineStyle
+
+	// GlyphStyle is the style of the glyphs drawn
+	// at each point.
+	GlyphStyle draw.GlyphStyle
+}
+
+// NewMarkedLine returns a MarkedLine that uses the
+// default line and glyph styles.
+func NewMarkedLine(xys XYer) (*MarkedLine, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &MarkedLine{
+		XYs:        data,
+		LineStyle:  DefaultLineStyle,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot draws the MarkedLine, implementing the
+// plot.Plotter interface.
+func (pts *MarkedLine) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	ps := make([]vg.Point, len(pts.XYs))
+	for i, p := range pts.XYs {
+		ps[i].X = trX(p.X)
+		ps[i].Y = trY(p.Y)
+	}
+	c.StrokeLines(pts.LineStyle, c.ClipLinesXY(ps)...)
+	for _, p := range ps {
+		c.DrawGlyph(pts.GlyphStyle, p)
+	}
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger
+// interface.
+func (pts *MarkedLine) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(pts)
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes for
+// the glyphs, implementing the plot.GlyphBoxer interface.
+func (pts *MarkedLine) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	r := pts.GlyphStyle.Radius
+	bs := make([]plot.GlyphBox, len(pts.XYs))
+	for i, p := range pts.XYs {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -r, Y: -r},
+			Max: vg.Point{X: +r, Y: +r},
+		}
+	}
+	return bs
+}
+
+// Thumbnail draws a short line with a glyph at its
+// center, implementing the plot.Thumbnailer interface.
+func (pts *MarkedLine) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(pts.LineStyle, c.Min.X, y, c.Max.X, y)
+	c.DrawGlyph(pts.GlyphStyle, c.Center())
+}
@@ -0,0 +1,139 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExampleLine_stepLine draws the same data with each of
+// the step kinds.
+func ExampleLine_stepLine() {
+	data := XYs{{X: 0, Y: 1}, {X: 1, Y: 3}, {X: 2, Y: 2}, {X: 3, Y: 4}}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Step Lines"
+
+	for i, k := range []StepKind{NoStep, PreStep, MidStep, PostStep} {
+		shifted := make(XYs, len(data))
+		for j, d := range data {
+			shifted[j].X = d.X
+			shifted[j].Y = d.Y + 4*float64(i)
+		}
+		l, err := NewLine(shifted)
+		if err != nil {
+			log.Panic(err)
+		}
+		l.StepStyle = k
+		l.Color = color.NRGBA{R: uint8(60 * i), B: 255 - uint8(60*i), A: 255}
+		l.Width = vg.Points(1.5)
+		p.Add(l)
+	}
+
+	err = p.Save(200, 200, "testdata/stepLine.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLineStepLine(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLine_stepLine, t, "stepLine.png")
+}
+
+// ExampleMarkedLine draws a line with a glyph at each
+// of its points using a single plotter.
+func ExampleMarkedLine() {
+	data := XYs{{X: 0, Y: 1}, {X: 1, Y: 3}, {X: 2, Y: 2}, {X: 3, Y: 4}}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Marked Line"
+
+	l, err := NewMarkedLine(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.LineStyle.Color = color.RGBA{B: 255, A: 255}
+	l.GlyphStyle.Shape = draw.CircleGlyph{}
+	l.GlyphStyle.Color = color.RGBA{R: 255, A: 255}
+	l.GlyphStyle.Radius = vg.Points(4)
+	p.Add(l)
+	p.Legend.Add("marked", l)
+
+	err = p.Save(200, 200, "testdata/markedLine.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestMarkedLine(t *testing.T) {
+	cmpimg.CheckPlot(ExampleMarkedLine, t, "markedLine.png")
+}
+
+func TestLineGaps(t *testing.T) {
+	nan := math.NaN()
+	data := XYs{{X: 0, Y: 1}, {X: 1, Y: 3}, {X: 2, Y: nan}, {X: 3, Y: 4}, {X: 4, Y: nan}, {X: 5, Y: -2}, {X: 6, Y: 0}}
+	l, err := NewLine(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	xmin, xmax, ymin, ymax := l.DataRange()
+	if xmin != 0 || xmax != 6 || ymin != -2 || ymax != 4 {
+		t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[0, 6]x[-2, 4]", xmin, xmax, ymin, ymax)
+	}
+
+	segs := segments(l.XYs)
+	if len(segs) != 3 {
+		t.Fatalf("unexpected number of segments: got:%d want:3", len(segs))
+	}
+	for i, want := range []int{2, 1, 2} {
+		if len(segs[i]) != want {
+			t.Errorf("unexpected length of segment %d: got:%d want:%d", i, len(segs[i]), want)
+		}
+	}
+
+	if _, err := NewLine(XYs{{X: nan, Y: 1}}); err != ErrNaN {
+		t.Errorf("unexpected error for NaN x value: got:%v want:%v", err, ErrNaN)
+	}
+}
+
+func TestXWindow(t *testing.T) {
+	xys := make(XYs, 10)
+	for i := range xys {
+		xys[i].X = float64(i)
+	}
+	for _, test := range []struct {
+		min, max float64
+		lo, hi   int
+	}{
+		{min: 0, max: 9, lo: 0, hi: 10},
+		{min: 3, max: 5, lo: 2, hi: 7},
+		{min: 2.5, max: 5.5, lo: 2, hi: 7},
+		{min: -5, max: 0.5, lo: 0, hi: 2},
+		{min: 8.5, max: 20, lo: 8, hi: 10},
+		{min: 20, max: 30, lo: 9, hi: 10},
+		{min: -30, max: -20, lo: 0, hi: 1},
+	} {
+		lo, hi := xys.XWindow(test.min, test.max)
+		if lo != test.lo || hi != test.hi {
+			t.Errorf("unexpected window for [%v, %v]: got:[%d, %d) want:[%d, %d)",
+				test.min, test.max, lo, hi, test.lo, test.hi)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ParallelCoordinates implements the Plotter interface,
+// drawing a parallel coordinates plot of multivariate
+// data.  Each category of the data is drawn as a vertical
+// axis with its own range, and each observation is drawn
+// as a polyline connecting its values on the axes.
+//
+// The axes are placed at the X values 0, 1, 2, ..., so
+// they can be named with plot.Plot.NominalX, and the
+// values of each category are normalized to the Y range
+// [0, 1], so the Y axis of the plot is usually hidden
+// with plot.Plot.HideY.  A slopegraph is a parallel
+// coordinates plot of two categories.
+type ParallelCoordinates struct {
+	// Rows are the observations.  Rows[i][j] is
+	// the value of observation i in category j.
+	Rows [][]float64
+
+	// Ranges are the ranges of the axes, one for each
+	// category.  The values of a category are drawn at
+	// their distance along its axis as a fraction of
+	// its range.  NewParallelCoordinates sets the
+	// ranges to the minimum and maximum values of the
+	// categories.  Values in a category whose range is
+	// empty are drawn at the middle of its axis.
+	Ranges []struct{ Min, Max float64 }
+
+	// LineStyle is the default style of the
+	// polylines of the observations.
+	LineStyle draw.LineStyle
+
+	// RowStyle is a function that specifies the line
+	// style of the polyline of the observation with the
+	// given row index.  If RowStyle is nil, LineStyle is
+	// used for all observations.
+	RowStyle func(row int) draw.LineStyle
+
+	// AxisStyle is the style of the vertical axes.
+	AxisStyle draw.LineStyle
+
+	// Ticker generates the tick marks of each axis
+	// from its range.
+	Ticker plot.Ticker
+
+	// TickLength is the length of the major tick
+	// marks, drawn to the left of the axes.  Minor
+	// tick marks are half as long.
+	TickLength vg.Length
+
+	// TextStyle is the style of the tick labels,
+	// drawn to the left of the tick marks.
+	TextStyle draw.TextStyle
+}
+
+// NewParallelCoordinates returns a ParallelCoordinates
+// plotter for the given observations, each of which must
+// have a value for every category.
+func NewParallelCoordinates(rows [][]float64) (*ParallelCoordinates, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, ErrNoData
+	}
+	n := len(rows[0])
+	cpy := make([][]float64, len(rows))
+	for i, row := range rows {
+		if len(row) != n {
+			return nil, errors.New("plotter: rows have different numbers of categories")
+		}
+		if err := CheckFloats(row...); err != nil {
+			return nil, err
+		}
+		cpy[i] = append([]float64(nil), row...)
+	}
+
+	ranges := make([]struct{ Min, Max float64 }, n)
+	for j := range ranges {
+		ranges[j].Min = math.Inf(1)
+		ranges[j].Max = math.Inf(-1)
+		for _, row := range cpy {
+			ranges[j].Min = math.Min(ranges[j].Min, row[j])
+			ranges[j].Max = math.Max(ranges[j].Max, row[j])
+		}
+	}
+
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ParallelCoordinates{
+		Rows:       cpy,
+		Ranges:     ranges,
+		LineStyle:  DefaultLineStyle,
+		AxisStyle:  DefaultLineStyle,
+		Ticker:     plot.DefaultTicks{},
+		TickLength: vg.Points(4),
+		TextStyle: draw.TextStyle{
+			Font:   fnt,
+			XAlign: draw.XRight,
+			YAlign: draw.YCenter,
+		},
+	}, nil
+}
+
+// norm returns the value v of category j
+// normalized to the range of its axis.
+func (pc *ParallelCoordinates) norm(j int, v float64) float64 {
+	min, max := pc.Ranges[j].Min, pc.Ranges[j].Max
+	if min == max {
+		return 0.5
+	}
+	return (v - min) / (max - min)
+}
+
+// ticks returns the ticks of the axis of
+// category j that lie within its range.
+func (pc *ParallelCoordinates) ticks(j int) []plot.Tick {
+	min, max := pc.Ranges[j].Min, pc.Ranges[j].Max
+	if min == max {
+		return []plot.Tick{{Value: min, Label: strconv.FormatFloat(min, 'g', -1, 64)}}
+	}
+	var ticks []plot.Tick
+	for _, t := range pc.Ticker.Ticks(min, max) {
+		if t.Value < min || max < t.Value {
+			continue
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+// Plot draws the ParallelCoordinates, implementing
+// the plot.Plotter interface.
+func (pc *ParallelCoordinates) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	for i, row := range pc.Rows {
+		pts := make([]vg.Point, len(row))
+		for j, v := range row {
+			pts[j] = vg.Point{X: trX(float64(j)), Y: trY(pc.norm(j, v))}
+		}
+		sty := pc.LineStyle
+		if pc.RowStyle != nil {
+			sty = pc.RowStyle(i)
+		}
+		c.StrokeLines(sty, c.ClipLinesXY(pts)...)
+	}
+
+	for j := range pc.Ranges {
+		x := trX(float64(j))
+		if !c.ContainsX(x) {
+			continue
+		}
+		c.StrokeLine2(pc.AxisStyle, x, trY(0), x, trY(1))
+
+		var marks [][]vg.Point
+		for _, t := range pc.ticks(j) {
+			y := trY(pc.norm(j, t.Value))
+			l := pc.TickLength
+			if t.IsMinor() {
+				l /= 2
+			}
+			marks = append(marks, []vg.Point{{X: x - l, Y: y}, {X: x, Y: y}})
+			if !t.IsMinor() {
+				c.FillText(pc.TextStyle, vg.Point{X: x - l, Y: y}, t.Label)
+			}
+		}
+		c.StrokeLines(pc.AxisStyle, marks...)
+	}
+}
+
+// DataRange returns the positions of the first and
+// last axes as the X range and the normalized range
+// [0, 1] as the Y range, implementing the
+// plot.DataRanger interface.
+func (pc *ParallelCoordinates) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, float64(len(pc.Ranges) - 1), 0, 1
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes, one
+// for each tick label of the axes, implementing the
+// plot.GlyphBoxer interface.
+func (pc *ParallelCoordinates) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	var bs []plot.GlyphBox
+	for j := range pc.Ranges {
+		for _, t := range pc.ticks(j) {
+			if t.IsMinor() {
+				continue
+			}
+			r := pc.TextStyle.Rectangle(t.Label)
+			r.Min.X -= pc.TickLength
+			r.Max.X -= pc.TickLength
+			bs = append(bs, plot.GlyphBox{
+				X:         plt.X.Norm(float64(j)),
+				Y:         plt.Y.Norm(pc.norm(j, t.Value)),
+				Rectangle: r,
+			})
+		}
+	}
+	return bs
+}
+
+// Thumbnail draws a line in the given style down the
+// center of a DrawArea as a thumbnail representation
+// of the ParallelCoordinates, implementing the
+// plot.Thumbnailer interface.
+func (pc *ParallelCoordinates) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(pc.LineStyle, c.Min.X, y, c.Max.X, y)
+}
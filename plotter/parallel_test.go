@@ -0,0 +1,97 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExampleParallelCoordinates() {
+	rnd := rand.New(rand.NewSource(1))
+
+	// Measurements of two groups of specimens,
+	// with each measurement in its own units.
+	const n = 10
+	rows := make([][]float64, 2*n)
+	for i := range rows {
+		g := float64(i / n)
+		rows[i] = []float64{
+			5 + g + 0.4*rnd.NormFloat64(),
+			3 - 0.5*g + 0.3*rnd.NormFloat64(),
+			1.5 + 3*g + 0.5*rnd.NormFloat64(),
+			0.2 + g + 0.2*rnd.NormFloat64(),
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Parallel Coordinates"
+	p.NominalX("sepal length", "sepal width", "petal length", "petal width")
+	p.HideY()
+
+	pc, err := NewParallelCoordinates(rows)
+	if err != nil {
+		log.Panic(err)
+	}
+	pc.RowStyle = func(row int) draw.LineStyle {
+		sty := pc.LineStyle
+		sty.Color = color.RGBA{R: 255, A: 255}
+		if row >= n {
+			sty.Color = color.RGBA{B: 255, A: 255}
+		}
+		return sty
+	}
+	p.Add(pc)
+
+	err = p.Save(300, 200, "testdata/parallelCoordinates.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestParallelCoordinates(t *testing.T) {
+	cmpimg.CheckPlot(ExampleParallelCoordinates, t, "parallelCoordinates.png")
+}
+
+func TestNewParallelCoordinates(t *testing.T) {
+	_, err := NewParallelCoordinates([][]float64{{1, 2}, {3}})
+	if err == nil {
+		t.Error("expected error for ragged rows")
+	}
+	_, err = NewParallelCoordinates(nil)
+	if err != ErrNoData {
+		t.Errorf("unexpected error for no data: got:%v want:%v", err, ErrNoData)
+	}
+
+	pc, err := NewParallelCoordinates([][]float64{{1, 10, 4}, {3, -10, 4}, {2, 0, 4}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for j, want := range []struct{ Min, Max float64 }{{1, 3}, {-10, 10}, {4, 4}} {
+		if pc.Ranges[j] != want {
+			t.Errorf("unexpected range of category %d: got:%v want:%v", j, pc.Ranges[j], want)
+		}
+	}
+	for i, want := range [][]float64{{0, 1, 0.5}, {1, 0, 0.5}, {0.5, 0.5, 0.5}} {
+		for j, v := range pc.Rows[i] {
+			if got := pc.norm(j, v); got != want[j] {
+				t.Errorf("unexpected normalized value of row %d category %d: got:%v want:%v", i, j, got, want[j])
+			}
+		}
+	}
+	xmin, xmax, ymin, ymax := pc.DataRange()
+	if xmin != 0 || xmax != 2 || ymin != 0 || ymax != 1 {
+		t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[0, 2]x[0, 1]", xmin, xmax, ymin, ymax)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"image/color"
 	"math"
+	"sort"
 
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
@@ -152,6 +153,27 @@ func (xys XYs) XY(i int) (float64, float64) {
 	return xys[i].X, xys[i].Y
 }
 
+// XWindow returns the indices [lo, hi) of the points of xys,
+// which must be sorted by increasing x value, that lie within
+// the x range [min, max]. The nearest point on either side of
+// the range is included so that lines crossing the edges of the
+// range can be drawn to them. XWindow uses a binary search, so
+// points outside the range are not iterated over.
+func (xys XYs) XWindow(min, max float64) (lo, hi int) {
+	lo = sort.Search(len(xys), func(i int) bool { return xys[i].X >= min })
+	hi = sort.Search(len(xys), func(i int) bool { return xys[i].X > max })
+	if lo > 0 {
+		lo--
+	}
+	if hi < len(xys) {
+		hi++
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
+
 // XValues implements the Valuer interface,
 // returning the x value from an XYer.
 type XValues struct {
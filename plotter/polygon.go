@@ -30,10 +30,10 @@ type Polygon struct {
 
 // NewPolygon returns a polygon that uses the default line style and
 // no fill color, where xys are the rings of the polygon.
-// Different backends may render overlapping rings and self-intersections
-// differently, but all built-in backends treat inner rings
-// with the opposite winding order from the outer ring as
-// holes.
+// The polygon is filled using the nonzero winding rule, so
+// inner rings with the opposite winding order from the outer
+// ring are holes, while those with the same winding order,
+// and self-intersections, are filled.
 func NewPolygon(xys ...XYer) (*Polygon, error) {
 	data := make([]XYs, len(xys))
 	for i, d := range xys {
@@ -55,19 +55,16 @@ func ExamplePolygon_holes() {
 	p.Legend.ThumbnailWidth = vg.Points(10)
 
 	// Here we save the image in different file formats
-	// to show how each back end handles polygon holes.
-
-	// The vgimg backend treats both internal polygons
-	// as holes by default.
+	// to show that the back ends handle polygon holes
+	// in the same way.  All of them fill using the
+	// nonzero winding rule, so they treat the internal
+	// polygon with the opposite winding direction as a
+	// hole but do not consider the internal polygon with
+	// the same winding direction to be a hole.
 	err = p.Save(100, 100, "testdata/polygon_holes.png")
 	if err != nil {
 		log.Panic(err)
 	}
-
-	// The vgsvg, vgpdf, and vgeps backgrounds all treat
-	// the internal polygon with the opposite winding
-	// direction as a hole but do not consider the internal
-	// polygon with the same winding direction to be a hole.
 	err = p.Save(100, 100, "testdata/polygon_holes.svg")
 	if err != nil {
 		log.Panic(err)
@@ -0,0 +1,189 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// GridUV describes a vector field where the X and Y
+// coordinates are arranged on a rectangular grid.
+type GridUV interface {
+	// Dims returns the dimensions of the grid.
+	Dims() (c, r int)
+
+	// UV returns the components of the vector at (c, r).
+	// It will panic if c or r are out of bounds for the grid.
+	UV(c, r int) (u, v float64)
+
+	// X returns the coordinate for the column at the index x.
+	// It will panic if c is out of bounds for the grid.
+	X(c int) float64
+
+	// Y returns the coordinate for the row at the index r.
+	// It will panic if r is out of bounds for the grid.
+	Y(r int) float64
+}
+
+// quiverHeadAngle is the angle between the shaft and
+// each side of the arrowheads drawn by a Quiver.
+const quiverHeadAngle = math.Pi / 8
+
+// Quiver implements the Plotter interface, drawing
+// an arrow at each point of the grid of a vector field.
+// The arrows point in the direction of the vectors and
+// their lengths are proportional to the magnitudes of
+// the vectors.
+type Quiver struct {
+	GridUV GridUV
+
+	// Length is the length of the arrow drawn for the
+	// vector with the greatest magnitude in the field.
+	// The arrows of the other vectors are scaled
+	// proportionally.
+	Length vg.Length
+
+	// HeadLength is the length of the sides of the
+	// arrowheads.  Arrowheads are shortened to the
+	// length of arrows that are shorter than HeadLength.
+	HeadLength vg.Length
+
+	// LineStyle is the style of the arrows.
+	draw.LineStyle
+
+	// max is the greatest magnitude of the vectors.
+	max float64
+}
+
+// NewQuiver returns a Quiver that draws the vectors
+// of the given field using the default line style,
+// with the longest arrow drawn with length l.
+func NewQuiver(g GridUV, l vg.Length) *Quiver {
+	c, r := g.Dims()
+	var max float64
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			u, v := g.UV(i, j)
+			if m := math.Hypot(u, v); m > max {
+				max = m
+			}
+		}
+	}
+	return &Quiver{
+		GridUV:     g,
+		Length:     l,
+		HeadLength: vg.Points(4),
+		LineStyle:  DefaultLineStyle,
+		max:        max,
+	}
+}
+
+// arrow returns the offset from the tail to the tip
+// of the arrow drawn for the vector (u, v).
+func (q *Quiver) arrow(u, v float64) vg.Point {
+	if q.max == 0 || math.IsNaN(u) || math.IsNaN(v) {
+		return vg.Point{}
+	}
+	return vg.Point{
+		X: q.Length * vg.Length(u/q.max),
+		Y: q.Length * vg.Length(v/q.max),
+	}
+}
+
+// Plot implements the Plotter interface, drawing an
+// arrow from each grid point in the direction of
+// its vector.
+func (q *Quiver) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	cols, rows := q.GridUV.Dims()
+	for i := 0; i < cols; i++ {
+		for j := 0; j < rows; j++ {
+			tail := vg.Point{X: trX(q.GridUV.X(i)), Y: trY(q.GridUV.Y(j))}
+			if !c.Contains(tail) {
+				continue
+			}
+			d := q.arrow(q.GridUV.UV(i, j))
+			l := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+			if l == 0 {
+				continue
+			}
+			tip := tail.Add(d)
+			c.StrokeLines(q.LineStyle, c.ClipLinesXY([]vg.Point{tail, tip})...)
+
+			head := q.HeadLength
+			if head > l {
+				head = l
+			}
+			theta := math.Atan2(float64(d.Y), float64(d.X)) + math.Pi
+			side := func(a float64) vg.Point {
+				return tip.Add(vg.Point{
+					X: head * vg.Length(math.Cos(theta+a)),
+					Y: head * vg.Length(math.Sin(theta+a)),
+				})
+			}
+			c.StrokeLines(q.LineStyle, c.ClipLinesXY([]vg.Point{side(quiverHeadAngle), tip, side(-quiverHeadAngle)})...)
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface, returning
+// the extent of the grid.
+func (q *Quiver) DataRange() (xmin, xmax, ymin, ymax float64) {
+	c, r := q.GridUV.Dims()
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	for i := 0; i < c; i++ {
+		x := q.GridUV.X(i)
+		xmin, xmax = math.Min(xmin, x), math.Max(xmax, x)
+	}
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for j := 0; j < r; j++ {
+		y := q.GridUV.Y(j)
+		ymin, ymax = math.Min(ymin, y), math.Max(ymax, y)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface, returning
+// a box covering each arrow.
+func (q *Quiver) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	c, r := q.GridUV.Dims()
+	pad := q.LineStyle.Width/2 + q.HeadLength*vg.Length(math.Sin(quiverHeadAngle))
+	bs := make([]plot.GlyphBox, 0, c*r)
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			d := q.arrow(q.GridUV.UV(i, j))
+			bs = append(bs, plot.GlyphBox{
+				X: plt.X.Norm(q.GridUV.X(i)),
+				Y: plt.Y.Norm(q.GridUV.Y(j)),
+				Rectangle: vg.Rectangle{
+					Min: vg.Point{X: minLength(0, d.X) - pad, Y: minLength(0, d.Y) - pad},
+					Max: vg.Point{X: maxLength(0, d.X) + pad, Y: maxLength(0, d.Y) + pad},
+				},
+			})
+		}
+	}
+	return bs
+}
+
+func minLength(a, b vg.Length) vg.Length {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxLength(a, b vg.Length) vg.Length {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,81 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// rotation is a GridUV of the vector field (-y, x)
+// sampled on a square grid centered on the origin.
+type rotation struct{ n int }
+
+func (g rotation) Dims() (c, r int)           { return g.n, g.n }
+func (g rotation) UV(c, r int) (u, v float64) { return -g.Y(r), g.X(c) }
+func (g rotation) X(c int) float64            { return float64(c) - float64(g.n-1)/2 }
+func (g rotation) Y(r int) float64            { return float64(r) - float64(g.n-1)/2 }
+
+// ExampleQuiver draws the vector field of a rotation.
+func ExampleQuiver() {
+	q := NewQuiver(rotation{n: 9}, vg.Points(15))
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Quiver"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	p.Add(q)
+
+	err = p.Save(200, 200, "testdata/quiver.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestQuiver(t *testing.T) {
+	cmpimg.CheckPlot(ExampleQuiver, t, "quiver.png")
+}
+
+func TestQuiverGlyphBoxes(t *testing.T) {
+	q := NewQuiver(rotation{n: 3}, vg.Points(10))
+	q.HeadLength = 0
+	q.Width = 0
+
+	xmin, xmax, ymin, ymax := q.DataRange()
+	if xmin != -1 || xmax != 1 || ymin != -1 || ymax != 1 {
+		t.Errorf("unexpected data range: got:[%v,%v]x[%v,%v] want:[-1,1]x[-1,1]", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = -1, 1
+	p.Y.Min, p.Y.Max = -1, 1
+	bs := q.GlyphBoxes(p)
+	if len(bs) != 9 {
+		t.Fatalf("unexpected number of glyph boxes: got:%d want:9", len(bs))
+	}
+
+	// The vector at (1, 0) is (0, 1), pointing up with
+	// half the magnitude of the corner vectors.
+	want := vg.Rectangle{Max: vg.Point{Y: vg.Points(10) / vg.Length(math.Sqrt2)}}
+	for _, b := range bs {
+		if b.X != 1 || b.Y != 0.5 {
+			continue
+		}
+		if math.Abs(float64(b.Max.Y-want.Max.Y)) > 1e-9 || b.Min != want.Min || b.Max.X != 0 {
+			t.Errorf("unexpected glyph box: got:%v want:%v", b.Rectangle, want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/gonum/stat/distuv"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Regression implements the Plotter interface, drawing
+// the least-squares line fitted to a set of points across
+// the X range of the plot.  A Regression does not
+// implement plot.DataRanger, so it does not change the
+// ranges of the axes.
+type Regression struct {
+	// Slope and Intercept are the parameters of
+	// the fitted line, y = Intercept + Slope*x.
+	Slope, Intercept float64
+
+	// RSquared is the coefficient of determination
+	// of the fit.
+	RSquared float64
+
+	// Confidence, if it is between zero and one, is
+	// the confidence level of a band drawn around the
+	// line for the mean of y, for example 0.95.  No band
+	// is drawn if Confidence is zero, or if the fit has
+	// no residual degrees of freedom.
+	Confidence float64
+
+	// BandColor is the color used to fill the
+	// confidence band.
+	BandColor color.Color
+
+	// LineStyle is the style of the fitted line.
+	draw.LineStyle
+
+	// n is the number of points that were fitted,
+	// meanX and sxx are the mean and the sum of the
+	// squared deviations of their x values, and
+	// residuals is the residual sum of squares.
+	n         int
+	meanX     float64
+	sxx       float64
+	residuals float64
+}
+
+// NewRegression returns a Regression for the least-squares
+// line fitted to the given points, drawn in the default
+// line style without a confidence band.  An error is
+// returned if there are fewer than two points or if all
+// of the points have the same x value.
+func NewRegression(xys XYer) (*Regression, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 {
+		return nil, errors.New("plotter: regression needs at least two points")
+	}
+
+	n := float64(len(data))
+	var meanX, meanY float64
+	for _, p := range data {
+		meanX += p.X
+		meanY += p.Y
+	}
+	meanX /= n
+	meanY /= n
+
+	var sxx, sxy, syy float64
+	for _, p := range data {
+		dx, dy := p.X-meanX, p.Y-meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return nil, errors.New("plotter: regression x values are all equal")
+	}
+
+	r := &Regression{
+		Slope:     sxy / sxx,
+		BandColor: color.Gray{Y: 200},
+		LineStyle: DefaultLineStyle,
+		n:         len(data),
+		meanX:     meanX,
+		sxx:       sxx,
+	}
+	r.Intercept = meanY - r.Slope*meanX
+	r.residuals = math.Max(syy-r.Slope*sxy, 0)
+	r.RSquared = 1
+	if syy > 0 {
+		r.RSquared = 1 - r.residuals/syy
+	}
+	return r, nil
+}
+
+// Y returns the y value of the fitted line at x.
+func (r *Regression) Y(x float64) float64 {
+	return r.Intercept + r.Slope*x
+}
+
+// bandWidth returns the half-width of the confidence
+// band at x, or zero if no band is drawn.
+func (r *Regression) bandWidth(x float64) float64 {
+	dof := r.n - 2
+	if r.Confidence <= 0 || r.Confidence >= 1 || dof < 1 {
+		return 0
+	}
+	t := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: float64(dof)}.Quantile((1 + r.Confidence) / 2)
+	s := math.Sqrt(r.residuals / float64(dof))
+	dx := x - r.meanX
+	return t * s * math.Sqrt(1/float64(r.n)+dx*dx/r.sxx)
+}
+
+// Plot draws the Regression, implementing the
+// plot.Plotter interface.  The confidence band is
+// drawn before the line.
+func (r *Regression) Plot(c draw.Canvas, plt *plot.Plot) {
+	// The line and band are sampled so that they
+	// follow the fit on non-linear axis scales.
+	const samples = 50
+	xs := make([]float64, samples)
+	for i := range xs {
+		xs[i] = plt.X.Min + (plt.X.Max-plt.X.Min)*float64(i)/(samples-1)
+	}
+
+	if r.bandWidth(r.meanX) > 0 {
+		top := make(XYs, samples)
+		bottom := make(XYs, samples)
+		for i, x := range xs {
+			w := r.bandWidth(x)
+			top[i].X, top[i].Y = x, r.Y(x)+w
+			bottom[i].X, bottom[i].Y = x, r.Y(x)-w
+		}
+		band := Band{Top: top, Bottom: bottom, FillColor: r.BandColor}
+		band.Plot(c, plt)
+	}
+
+	trX, trY := plt.Transforms(&c)
+	line := make([]vg.Point, samples)
+	for i, x := range xs {
+		line[i] = vg.Point{X: trX(x), Y: trY(r.Y(x))}
+	}
+	c.StrokeLines(r.LineStyle, c.ClipLinesXY(line)...)
+}
+
+// Thumbnail draws a line in the style of the fitted
+// line, implementing the plot.Thumbnailer interface.
+func (r *Regression) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(r.LineStyle, c.Min.X, y, c.Max.X, y)
+}
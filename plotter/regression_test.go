@@ -0,0 +1,88 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+// ExampleRegression draws noisy points with the line
+// fitted to them and its 95% confidence band.
+func ExampleRegression() {
+	rnd := rand.New(rand.NewSource(1))
+	pts := make(XYs, 30)
+	for i := range pts {
+		pts[i].X = 10 * rnd.Float64()
+		pts[i].Y = 1 + 0.5*pts[i].X + rnd.NormFloat64()
+	}
+
+	s, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	r, err := NewRegression(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	r.Confidence = 0.95
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = fmt.Sprintf("y = %.2f + %.2fx, R² = %.2f", r.Intercept, r.Slope, r.RSquared)
+	p.Add(r, s)
+
+	err = p.Save(200, 200, "testdata/regression.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestRegression(t *testing.T) {
+	cmpimg.CheckPlot(ExampleRegression, t, "regression.png")
+}
+
+func TestRegressionFit(t *testing.T) {
+	r, err := NewRegression(XYs{{X: 0, Y: 1}, {X: 1, Y: 3}, {X: 2, Y: 5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Slope != 2 || r.Intercept != 1 || r.RSquared != 1 {
+		t.Errorf("unexpected exact fit: got slope=%v intercept=%v R²=%v want 2, 1, 1",
+			r.Slope, r.Intercept, r.RSquared)
+	}
+
+	r, err = NewRegression(XYs{{X: 0, Y: 0}, {X: 1, Y: 2}, {X: 2, Y: 1}, {X: 3, Y: 3}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const tol = 1e-12
+	if math.Abs(r.Slope-0.8) > tol || math.Abs(r.Intercept-0.3) > tol || math.Abs(r.RSquared-0.64) > tol {
+		t.Errorf("unexpected fit: got slope=%v intercept=%v R²=%v want 0.8, 0.3, 0.64",
+			r.Slope, r.Intercept, r.RSquared)
+	}
+	if w := r.bandWidth(1.5); w != 0 {
+		t.Errorf("unexpected band without confidence level: got:%v", w)
+	}
+	r.Confidence = 0.95
+	if r.bandWidth(1.5) >= r.bandWidth(3) {
+		t.Errorf("confidence band not narrowest at the mean of x")
+	}
+
+	if _, err := NewRegression(XYs{{X: 1, Y: 1}}); err == nil {
+		t.Error("expected error for a single point")
+	}
+	if _, err := NewRegression(XYs{{X: 1, Y: 1}, {X: 1, Y: 2}}); err == nil {
+		t.Error("expected error for equal x values")
+	}
+}
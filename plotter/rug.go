@@ -0,0 +1,116 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Rug implements the Plotter interface, drawing a short
+// tick mark at each of a set of values along an edge of
+// the data area, showing the marginal distribution of
+// the values.
+type Rug struct {
+	// Values is a copy of the values.
+	Values
+
+	// Vertical specifies that the values are Y
+	// values, marked along the left edge of the
+	// data area.  Otherwise they are X values,
+	// marked along the bottom edge.
+	Vertical bool
+
+	// Length is the length of the marks.
+	Length vg.Length
+
+	// LineStyle is the style of the marks.
+	draw.LineStyle
+}
+
+// NewRug returns a Rug that marks the given values
+// along the bottom edge of the data area.
+func NewRug(vs Valuer) (*Rug, error) {
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	return &Rug{
+		Values:    values,
+		Length:    vg.Points(5),
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Plot draws the Rug, implementing the plot.Plotter
+// interface.
+func (r *Rug) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	var marks [][]vg.Point
+	for _, v := range r.Values {
+		if r.Vertical {
+			y := trY(v)
+			if !c.ContainsY(y) {
+				continue
+			}
+			marks = append(marks, []vg.Point{{X: c.Min.X, Y: y}, {X: c.Min.X + r.Length, Y: y}})
+			continue
+		}
+		x := trX(v)
+		if !c.ContainsX(x) {
+			continue
+		}
+		marks = append(marks, []vg.Point{{X: x, Y: c.Min.Y}, {X: x, Y: c.Min.Y + r.Length}})
+	}
+	c.StrokeLines(r.LineStyle, marks...)
+}
+
+// DataRange returns the minimum and maximum values,
+// as the X range of a horizontal Rug or the Y range of
+// a vertical Rug, implementing the plot.DataRanger
+// interface.  The other range is empty.
+func (r *Rug) DataRange() (xmin, xmax, ymin, ymax float64) {
+	min, max := Range(r.Values)
+	if r.Vertical {
+		return math.Inf(1), math.Inf(-1), min, max
+	}
+	return min, max, math.Inf(1), math.Inf(-1)
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes, one
+// covering the width of each mark, so that the marks
+// at the ends of the axis are not clipped, implementing
+// the plot.GlyphBoxer interface.
+func (r *Rug) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(r.Values))
+	w := r.Width / 2
+	for i, v := range r.Values {
+		if r.Vertical {
+			bs[i].Y = plt.Y.Norm(v)
+			bs[i].Rectangle = vg.Rectangle{
+				Min: vg.Point{Y: -w},
+				Max: vg.Point{Y: +w},
+			}
+			continue
+		}
+		bs[i].X = plt.X.Norm(v)
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -w},
+			Max: vg.Point{X: +w},
+		}
+	}
+	return bs
+}
+
+// Thumbnail draws a mark in the center of the
+// canvas, implementing the plot.Thumbnailer
+// interface.
+func (r *Rug) Thumbnail(c *draw.Canvas) {
+	x := c.Center().X
+	c.StrokeLine2(r.LineStyle, x, c.Min.Y, x, c.Max.Y)
+}
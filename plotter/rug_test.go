@@ -0,0 +1,89 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+func ExampleRug() {
+	rnd := rand.New(rand.NewSource(1))
+
+	// Correlated points, with the marginal
+	// distributions shown by rugs.
+	const n = 50
+	pts := make(XYs, n)
+	xs := make(Values, n)
+	ys := make(Values, n)
+	for i := range pts {
+		pts[i].X = rnd.NormFloat64()
+		pts[i].Y = pts[i].X + 0.5*rnd.NormFloat64()
+		xs[i], ys[i] = pts[i].X, pts[i].Y
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Rugs"
+
+	s, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	xrug, err := NewRug(xs)
+	if err != nil {
+		log.Panic(err)
+	}
+	yrug, err := NewRug(ys)
+	if err != nil {
+		log.Panic(err)
+	}
+	yrug.Vertical = true
+	p.Add(s, xrug, yrug)
+
+	err = p.Save(200, 200, "testdata/rug.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestRug(t *testing.T) {
+	cmpimg.CheckPlot(ExampleRug, t, "rug.png")
+}
+
+func TestRugDataRange(t *testing.T) {
+	r, err := NewRug(Values{3, -1, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := r.DataRange()
+	if xmin != -1 || xmax != 3 || !math.IsInf(ymin, 1) || !math.IsInf(ymax, -1) {
+		t.Errorf("unexpected data range: got:[%v, %v]x[%v, %v] want:[-1, 3]x[+Inf, -Inf]", xmin, xmax, ymin, ymax)
+	}
+
+	r.Vertical = true
+	xmin, xmax, ymin, ymax = r.DataRange()
+	if !math.IsInf(xmin, 1) || !math.IsInf(xmax, -1) || ymin != -1 || ymax != 3 {
+		t.Errorf("unexpected vertical data range: got:[%v, %v]x[%v, %v] want:[+Inf, -Inf]x[-1, 3]", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(r)
+	for i, b := range r.GlyphBoxes(p) {
+		if b.Size().X != 0 || b.Size().Y != r.Width {
+			t.Errorf("unexpected size of glyph box %d: got:%v want:{0 %v}", i, b.Size(), r.Width)
+		}
+	}
+}
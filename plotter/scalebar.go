@@ -0,0 +1,132 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ScaleBar implements the Plotter interface, drawing a
+// labelled bar that spans a given distance along the x
+// axis at a corner of the data area.  A scale bar shows
+// the size of the plotted data when the axes are hidden,
+// as is usual for maps and images.  The bar is only
+// meaningful for a linear x axis.
+type ScaleBar struct {
+	// Length is the length of the bar in
+	// x data units.
+	Length float64
+
+	// Label is the text drawn above the bar.
+	Label string
+
+	// Top and Left specify the corner of the data
+	// area at which the bar is drawn, in the same way
+	// as for a plot.Legend.
+	Top, Left bool
+
+	// Padding is the distance between the bar,
+	// including its label, and the edges of the
+	// data area.
+	Padding vg.Length
+
+	// EndLength is the length of the marks
+	// drawn across the ends of the bar.
+	EndLength vg.Length
+
+	// LabelPadding is the distance between the
+	// bar and its label.
+	LabelPadding vg.Length
+
+	// LineStyle is the style of the bar.
+	draw.LineStyle
+
+	// TextStyle is the style of the label.
+	TextStyle draw.TextStyle
+}
+
+// NewScaleBar returns a ScaleBar of the given length in
+// x data units with the given label, drawn at the lower
+// right corner of the data area.
+func NewScaleBar(length float64, label string) (*ScaleBar, error) {
+	if !(length > 0) {
+		return nil, errors.New("plotter: scale bar length must be positive")
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ScaleBar{
+		Length:       length,
+		Label:        label,
+		Padding:      vg.Points(5),
+		EndLength:    vg.Points(5),
+		LabelPadding: vg.Points(2),
+		LineStyle:    DefaultLineStyle,
+		TextStyle:    draw.TextStyle{Font: fnt, XAlign: draw.XCenter},
+	}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (b *ScaleBar) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, _ := plt.Transforms(&c)
+	length := trX(plt.X.Min+b.Length) - trX(plt.X.Min)
+
+	x0 := c.Min.X + b.Padding
+	if !b.Left {
+		x0 = c.Max.X - b.Padding - length
+	}
+	x1 := x0 + length
+	y := c.Min.Y + b.Padding + b.EndLength/2
+	if b.Top {
+		y = c.Max.Y - b.Padding - b.height() + b.EndLength/2
+	}
+
+	c.StrokeLine2(b.LineStyle, x0, y, x1, y)
+	if b.EndLength > 0 {
+		h := b.EndLength / 2
+		c.StrokeLine2(b.LineStyle, x0, y-h, x0, y+h)
+		c.StrokeLine2(b.LineStyle, x1, y-h, x1, y+h)
+	}
+	if b.Label != "" {
+		pt := vg.Point{X: (x0 + x1) / 2, Y: y + b.EndLength/2 + b.LabelPadding}
+		c.FillText(b.TextStyle, pt, b.Label)
+	}
+}
+
+// height returns the height of the bar
+// including its end marks and label.
+func (b *ScaleBar) height() vg.Length {
+	h := b.EndLength
+	if b.Label != "" {
+		h += b.LabelPadding + b.TextStyle.Height(b.Label)
+	}
+	return h
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// returning a box at the corner of the data area that
+// holds the bar's padding and height, so that the bar
+// is not clipped by the edges of the data area.
+func (b *ScaleBar) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	box := plot.GlyphBox{
+		Rectangle: vg.Rectangle{
+			Max: vg.Point{X: b.Padding, Y: b.Padding + b.height()},
+		},
+	}
+	if !b.Left {
+		box.X = 1
+		box.Rectangle.Min.X, box.Rectangle.Max.X = -b.Padding, 0
+	}
+	if b.Top {
+		box.Y = 1
+		box.Rectangle.Min.Y, box.Rectangle.Max.Y = -b.Padding-b.height(), 0
+	}
+	return []plot.GlyphBox{box}
+}
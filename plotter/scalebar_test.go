@@ -0,0 +1,66 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleScaleBar draws a circle with hidden axes,
+// using a scale bar to show its size.
+func ExampleScaleBar() {
+	pts := make(XYs, 65)
+	for i := range pts {
+		theta := 2 * math.Pi * float64(i) / float64(len(pts)-1)
+		pts[i].X = 10 * math.Cos(theta)
+		pts[i].Y = 10 * math.Sin(theta)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Scale Bar"
+	p.HideAxes()
+	p.X.Min, p.X.Max = -12, 12
+	p.Y.Min, p.Y.Max = -12, 12
+
+	l, err := NewLine(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Width = vg.Points(1)
+
+	bar, err := NewScaleBar(5, "5 km")
+	if err != nil {
+		log.Panic(err)
+	}
+	bar.Width = vg.Points(1.5)
+
+	p.Add(l, bar)
+
+	err = p.Save(200, 200, "testdata/scaleBar.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestScaleBar(t *testing.T) {
+	cmpimg.CheckPlot(ExampleScaleBar, t, "scaleBar.png")
+}
+
+func TestNewScaleBar(t *testing.T) {
+	for _, length := range []float64{0, -1, math.NaN()} {
+		if _, err := NewScaleBar(length, ""); err == nil {
+			t.Errorf("expected error for scale bar length %v", length)
+		}
+	}
+}
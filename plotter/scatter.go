@@ -23,6 +23,12 @@ type Scatter struct {
 	// GlyphStyle is the style of the glyphs drawn
 	// at each point.
 	draw.GlyphStyle
+
+	// SortedX specifies that the points are sorted by
+	// increasing x value. When SortedX is true, points
+	// outside the x range of the plot are skipped when
+	// drawing.
+	SortedX bool
 }
 
 // NewScatter returns a Scatter that uses the
@@ -46,7 +52,12 @@ func (pts *Scatter) Plot(c draw.Canvas, plt *plot.Plot) {
 	if pts.GlyphStyleFunc != nil {
 		glyph = pts.GlyphStyleFunc
 	}
-	for i, p := range pts.XYs {
+	lo, hi := 0, len(pts.XYs)
+	if pts.SortedX {
+		lo, hi = pts.XYs.XWindow(plt.X.Min, plt.X.Max)
+	}
+	for i := lo; i < hi; i++ {
+		p := pts.XYs[i]
 		c.DrawGlyph(glyph(i), vg.Point{X: trX(p.X), Y: trY(p.Y)})
 	}
 }
@@ -14,6 +14,7 @@ import (
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
 )
 
 // ExampleScatter draws some scatter points, a line,
@@ -87,3 +88,33 @@ func ExampleScatter() {
 func TestScatter(t *testing.T) {
 	cmpimg.CheckPlot(ExampleScatter, t, "scatter.png")
 }
+
+func TestScatterSortedX(t *testing.T) {
+	xys := make(XYs, 1000)
+	for i := range xys {
+		xys[i].X = float64(i)
+		xys[i].Y = float64(i % 10)
+	}
+	s, err := NewScatter(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.SortedX = true
+	var drawn []int
+	s.GlyphStyleFunc = func(i int) draw.GlyphStyle {
+		drawn = append(drawn, i)
+		return s.GlyphStyle
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 990, 995
+	p.Y.Min, p.Y.Max = 0, 9
+	s.Plot(draw.New(vgimg.New(100, 100)), p)
+
+	if len(drawn) != 8 || drawn[0] != 989 || drawn[len(drawn)-1] != 996 {
+		t.Errorf("unexpected points drawn: got:%v want:[989, 996]", drawn)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// SigmaBands implements the Plotter interface, drawing
+// a line at a mean value across the X range of the plot,
+// with symmetric shaded bands at whole multiples of a
+// standard deviation around it, as in a control chart.
+type SigmaBands struct {
+	// Mean is the Y value of the center line.
+	Mean float64
+
+	// Sigma is the standard deviation that
+	// gives the width of each band.
+	Sigma float64
+
+	// Colors are the fill colors of the bands.  The
+	// band drawn with the ith color covers the Y values
+	// between i*Sigma and (i+1)*Sigma from the Mean on
+	// each side.  A nil color leaves its band unfilled.
+	Colors []color.Color
+
+	// LineStyle is the style of the center line.
+	draw.LineStyle
+}
+
+// NewSigmaBands returns SigmaBands with the given number
+// of levels of bands around mean, each sigma wide, filled
+// with translucent grays that fade with distance from the
+// mean.  An error is returned if sigma is negative or if
+// there are no levels.
+func NewSigmaBands(mean, sigma float64, levels int) (*SigmaBands, error) {
+	if err := CheckFloats(mean, sigma); err != nil {
+		return nil, err
+	}
+	if sigma < 0 {
+		return nil, errors.New("plotter: negative sigma")
+	}
+	if levels < 1 {
+		return nil, errors.New("plotter: no sigma band levels")
+	}
+	colors := make([]color.Color, levels)
+	for i := range colors {
+		colors[i] = color.NRGBA{R: 128, G: 128, B: 128, A: uint8(128 / (i + 1))}
+	}
+	return &SigmaBands{
+		Mean:      mean,
+		Sigma:     sigma,
+		Colors:    colors,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Plot draws the SigmaBands, implementing the
+// plot.Plotter interface.  The bands do not overlap,
+// so that translucent colors are not compounded.
+func (s *SigmaBands) Plot(c draw.Canvas, plt *plot.Plot) {
+	_, trY := plt.Transforms(&c)
+	fill := func(col color.Color, lo, hi float64) {
+		ylo, yhi := trY(lo), trY(hi)
+		pts := []vg.Point{
+			{X: c.Min.X, Y: ylo},
+			{X: c.Max.X, Y: ylo},
+			{X: c.Max.X, Y: yhi},
+			{X: c.Min.X, Y: yhi},
+		}
+		c.FillPolygon(col, c.ClipPolygonY(pts))
+	}
+	for i, col := range s.Colors {
+		if col == nil {
+			continue
+		}
+		inner := float64(i) * s.Sigma
+		outer := float64(i+1) * s.Sigma
+		if i == 0 {
+			fill(col, s.Mean-outer, s.Mean+outer)
+			continue
+		}
+		fill(col, s.Mean+inner, s.Mean+outer)
+		fill(col, s.Mean-outer, s.Mean-inner)
+	}
+
+	if y := trY(s.Mean); c.ContainsY(y) {
+		c.StrokeLine2(s.LineStyle, c.Min.X, y, c.Max.X, y)
+	}
+}
+
+// DataRange returns the Y range covered by the outermost
+// band, implementing the plot.DataRanger interface.  The
+// X range is empty since the bands span the X range of
+// the plot.
+func (s *SigmaBands) DataRange() (xmin, xmax, ymin, ymax float64) {
+	w := float64(len(s.Colors)) * s.Sigma
+	return math.Inf(1), math.Inf(-1), s.Mean - w, s.Mean + w
+}
+
+// Thumbnail draws the center line over the innermost
+// band, implementing the plot.Thumbnailer interface.
+func (s *SigmaBands) Thumbnail(c *draw.Canvas) {
+	if len(s.Colors) > 0 && s.Colors[0] != nil {
+		pts := []vg.Point{
+			{X: c.Min.X, Y: c.Min.Y},
+			{X: c.Max.X, Y: c.Min.Y},
+			{X: c.Max.X, Y: c.Max.Y},
+			{X: c.Min.X, Y: c.Max.Y},
+		}
+		c.FillPolygon(s.Colors[0], c.ClipPolygonY(pts))
+	}
+	y := c.Center().Y
+	c.StrokeLine2(s.LineStyle, c.Min.X, y, c.Max.X, y)
+}
@@ -0,0 +1,86 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+func ExampleSigmaBands() {
+	rnd := rand.New(rand.NewSource(1))
+
+	// Create a series of measurements from a process
+	// with mean 10 and standard deviation 1.
+	const n = 30
+	pts := make(XYs, n)
+	for i := range pts {
+		pts[i].X = float64(i)
+		pts[i].Y = 10 + rnd.NormFloat64()
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Control chart"
+
+	bands, err := NewSigmaBands(10, 1, 3)
+	if err != nil {
+		log.Panic(err)
+	}
+	bands.Colors[2] = color.NRGBA{R: 255, A: 48}
+
+	l, s, err := NewLinePoints(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(bands, l, s)
+
+	err = p.Save(300, 200, "testdata/sigmaBands.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSigmaBands(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSigmaBands, t, "sigmaBands.png")
+
+	b, err := NewSigmaBands(5, 2, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _, ymin, ymax := b.DataRange()
+	if ymin != 1 || ymax != 9 {
+		t.Errorf("unexpected y range: got:[%v, %v] want:[1, 9]", ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax := p.X.Min, p.X.Max
+	p.Add(b)
+	if p.X.Min != xmin || p.X.Max != xmax {
+		t.Errorf("unexpected x range: got:[%v, %v] want:[%v, %v]", p.X.Min, p.X.Max, xmin, xmax)
+	}
+
+	for _, test := range []struct {
+		sigma  float64
+		levels int
+	}{
+		{sigma: -1, levels: 1},
+		{sigma: 1, levels: 0},
+	} {
+		if _, err := NewSigmaBands(0, test.sigma, test.levels); err == nil {
+			t.Errorf("expected error for sigma=%v levels=%d", test.sigma, test.levels)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/tools/bezier"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// splineClipSegments is the number of line segments used
+// to approximate a spline segment that must be clipped.
+const splineClipSegments = 32
+
+// Spline implements the Plotter interface, drawing a smooth
+// Catmull-Rom curve that passes through each of its points.
+type Spline struct {
+	// XYs is a copy of the points for this spline.
+	XYs
+
+	// LineStyle is the style of the curve.
+	draw.LineStyle
+}
+
+// NewSpline returns a Spline that uses the default line style.
+func NewSpline(xys XYer) (*Spline, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &Spline{
+		XYs:       data,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Plot draws the Spline, implementing the plot.Plotter
+// interface.
+//
+// The curve between each pair of consecutive points is
+// drawn as a cubic Bézier curve whose tangents are given
+// by the neighboring points.  The end points are treated
+// as their own neighbors.
+func (s *Spline) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	ps := make([]vg.Point, len(s.XYs))
+	for i, p := range s.XYs {
+		ps[i].X = trX(p.X)
+		ps[i].Y = trY(p.Y)
+	}
+	if len(ps) < 3 {
+		c.StrokeLines(s.LineStyle, c.ClipLinesXY(ps)...)
+		return
+	}
+
+	c.SetLineStyle(s.LineStyle)
+	var (
+		pa      vg.Path
+		clipped [][]vg.Point
+	)
+	for i := 0; i < len(ps)-1; i++ {
+		p0, p1 := ps[i], ps[i+1]
+		prev, next := p0, p1
+		if i > 0 {
+			prev = ps[i-1]
+		}
+		if i < len(ps)-2 {
+			next = ps[i+2]
+		}
+		c1 := p0.Add(p1.Sub(prev).Scale(1.0 / 6))
+		c2 := p1.Sub(next.Sub(p0).Scale(1.0 / 6))
+
+		// A Bézier curve lies within the convex hull
+		// of its control points, so it does not need
+		// clipping when they are all within the canvas.
+		if c.Contains(p0) && c.Contains(c1) && c.Contains(c2) && c.Contains(p1) {
+			if len(pa) == 0 {
+				pa.Move(p0)
+			}
+			pa.CubeTo(c1, c2, p1)
+			continue
+		}
+		if len(pa) > 0 {
+			c.Stroke(pa)
+			pa = nil
+		}
+		seg := bezier.New(p0, c1, c2, p1).Curve(make([]vg.Point, splineClipSegments+1))
+		clipped = append(clipped, c.ClipLinesXY(seg)...)
+	}
+	if len(pa) > 0 {
+		c.Stroke(pa)
+	}
+	if len(clipped) > 0 {
+		c.StrokeLines(s.LineStyle, clipped...)
+	}
+}
+
+// DataRange returns the minimum and maximum
+// x and y values of the spline's points,
+// implementing the plot.DataRanger interface.
+// The curve may extend slightly beyond the range.
+func (s *Spline) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(s)
+}
+
+// Thumbnail draws the thumbnail for the Spline,
+// implementing the plot.Thumbnailer interface.
+func (s *Spline) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(s.LineStyle, c.Min.X, y, c.Max.X, y)
+}
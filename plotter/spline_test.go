@@ -0,0 +1,52 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+)
+
+// ExampleSpline draws a smooth curve through a set of points.
+func ExampleSpline() {
+	pts := XYs{
+		{X: 0, Y: 0}, {X: 1, Y: 3}, {X: 2, Y: 1},
+		{X: 3, Y: 4}, {X: 4, Y: 2}, {X: 5, Y: 2.5},
+	}
+
+	s, err := NewSpline(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Color = color.NRGBA{B: 255, A: 255}
+
+	sc, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Spline"
+	p.Add(s, sc)
+
+	// Zoom in so that part of the curve is clipped.
+	p.X.Max = 4.5
+
+	err = p.Save(200, 200, "testdata/spline.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSpline(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSpline, t, "spline.png")
+}
@@ -0,0 +1,85 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"reflect"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ApplyPlotStyles gives the plotters the DefaultLineStyle and
+// DefaultGlyphStyle of the plot in place of this package's
+// DefaultLineStyle and DefaultGlyphStyle.  Styles that differ
+// from this package's defaults, having been set by the caller,
+// are kept.  A style that was set to be the same as this
+// package's default cannot be told apart from an unset style,
+// and is replaced, so plotters that should keep the package
+// defaults should not be passed to ApplyPlotStyles.  Plot
+// styles whose Color is nil are not applied.
+//
+// The styles of Line, Function, Spline, Polygon and Scatter
+// plotters are applied.  Other plotters are left unchanged.
+func ApplyPlotStyles(p *plot.Plot, plotters ...plot.Plotter) {
+	line := func(sty *draw.LineStyle) {
+		if p.DefaultLineStyle.Color != nil && isDefaultLineStyle(*sty) {
+			*sty = p.DefaultLineStyle
+		}
+	}
+	glyph := func(sty *draw.GlyphStyle) {
+		if p.DefaultGlyphStyle.Color != nil && isDefaultGlyphStyle(*sty) {
+			*sty = p.DefaultGlyphStyle
+		}
+	}
+	for _, d := range plotters {
+		switch d := d.(type) {
+		case *Line:
+			line(&d.LineStyle)
+		case *Function:
+			line(&d.LineStyle)
+		case *Spline:
+			line(&d.LineStyle)
+		case *Polygon:
+			line(&d.LineStyle)
+		case *Scatter:
+			glyph(&d.GlyphStyle)
+		}
+	}
+}
+
+// isDefaultLineStyle returns whether sty is
+// the same as DefaultLineStyle.
+func isDefaultLineStyle(sty draw.LineStyle) bool {
+	def := DefaultLineStyle
+	if sty.Color != def.Color || sty.Width != def.Width || sty.DashOffs != def.DashOffs || len(sty.Dashes) != len(def.Dashes) {
+		return false
+	}
+	for i, d := range sty.Dashes {
+		if d != def.Dashes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isDefaultGlyphStyle returns whether sty is
+// the same as DefaultGlyphStyle.  Glyph shapes
+// of different or non-comparable types are not
+// the same.
+func isDefaultGlyphStyle(sty draw.GlyphStyle) bool {
+	def := DefaultGlyphStyle
+	if sty.Color != def.Color || sty.Radius != def.Radius {
+		return false
+	}
+	if sty.Shape == nil || def.Shape == nil {
+		return sty.Shape == def.Shape
+	}
+	typ := reflect.TypeOf(sty.Shape)
+	if typ != reflect.TypeOf(def.Shape) || !typ.Comparable() {
+		return false
+	}
+	return sty.Shape == def.Shape
+}
@@ -0,0 +1,73 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func TestApplyPlotStyles(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	p.DefaultLineStyle = draw.LineStyle{Color: red, Width: vg.Points(2)}
+	p.DefaultGlyphStyle = draw.GlyphStyle{Color: red, Radius: vg.Points(3), Shape: draw.CrossGlyph{}}
+
+	l, s, err := NewLinePoints(XYs{{X: 0, Y: 0}, {X: 1, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f := NewFunction(math.Sin)
+	f.Color = blue
+
+	ApplyPlotStyles(p, l, s, f)
+	if l.Color != red || l.Width != vg.Points(2) {
+		t.Errorf("plot line style not applied: got:%+v", l.LineStyle)
+	}
+	if s.GlyphStyle != p.DefaultGlyphStyle {
+		t.Errorf("plot glyph style not applied: got:%+v", s.GlyphStyle)
+	}
+	if f.Color != blue || f.Width != DefaultLineStyle.Width {
+		t.Errorf("overridden line style replaced: got:%+v", f.LineStyle)
+	}
+}
+
+// sliceGlyph is a GlyphDrawer of a
+// non-comparable type.
+type sliceGlyph []vg.Point
+
+func (sliceGlyph) DrawGlyph(*draw.Canvas, draw.GlyphStyle, vg.Point) {}
+
+func TestApplyPlotStylesNonComparableShape(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	red := color.NRGBA{R: 255, A: 255}
+	p.DefaultGlyphStyle = draw.GlyphStyle{Color: red, Radius: vg.Points(3), Shape: draw.CrossGlyph{}}
+
+	// Comparing glyph styles whose shapes have the same
+	// non-comparable type must not panic.
+	defer func(shape draw.GlyphDrawer) { DefaultGlyphStyle.Shape = shape }(DefaultGlyphStyle.Shape)
+	DefaultGlyphStyle.Shape = sliceGlyph{{X: 1, Y: 1}}
+
+	s, err := NewScatter(XYs{{X: 0, Y: 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ApplyPlotStyles(p, s)
+	if _, ok := s.Shape.(sliceGlyph); !ok || s.Color != DefaultGlyphStyle.Color {
+		t.Errorf("glyph style with non-comparable shape replaced: got:%+v", s.GlyphStyle)
+	}
+}
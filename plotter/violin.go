@@ -0,0 +1,287 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// DefaultViolinSamples is the default number of points
+// at which the density of a Violin is evaluated.
+const DefaultViolinSamples = 100
+
+// Violin implements the Plotter interface, drawing
+// a violin plot to represent the distribution of values.
+// The shape of the violin is a Gaussian kernel density
+// estimate of the values, mirrored around its location.
+type Violin struct {
+	// Values is a copy of the values used to
+	// create this violin plot.
+	Values
+
+	// Location is the location of the violin along its axis.
+	Location float64
+
+	// Median is the median value of the data.
+	Median float64
+
+	// Min and Max are the extreme values of the data.
+	// The density is drawn between Min and Max.
+	Min, Max float64
+
+	// Bandwidth is the bandwidth of the kernel density
+	// estimate. If Bandwidth is zero, it is chosen using
+	// Silverman's rule of thumb.
+	Bandwidth float64
+
+	// Samples is the number of points at which the density
+	// is evaluated to draw the violin.
+	Samples int
+
+	// Offset is added to the location of the violin.
+	// When the Offset is zero, the violin is drawn
+	// centered at its location.
+	Offset vg.Length
+
+	// Width is the width of the violin at
+	// its widest point.
+	Width vg.Length
+
+	// FillColor is the color used to fill the violin.
+	// If FillColor is nil, the violin is not filled.
+	FillColor color.Color
+
+	// LineStyle is the style of the outline of the violin.
+	LineStyle draw.LineStyle
+
+	// ShowMedian specifies whether a line is drawn
+	// across the violin at the median.
+	ShowMedian bool
+
+	// MedianStyle is the line style for the median line.
+	MedianStyle draw.LineStyle
+
+	// Horizontal dictates whether the Violin should be in the vertical
+	// (default) or horizontal direction.
+	Horizontal bool
+}
+
+// NewViolin returns a new Violin that represents the
+// distribution of the given values, drawn at loc with
+// the given maximum width.
+//
+// An error is returned if the violin is created with
+// no values.
+func NewViolin(w vg.Length, loc float64, values Valuer) (*Violin, error) {
+	if w < 0 {
+		return nil, errors.New("plotter: negative violin width")
+	}
+	vs, err := CopyValues(values)
+	if err != nil {
+		return nil, err
+	}
+	if len(vs) == 0 {
+		return nil, ErrNoData
+	}
+
+	sorted := make(Values, len(vs))
+	copy(sorted, vs)
+	sort.Float64s(sorted)
+
+	return &Violin{
+		Values:      vs,
+		Location:    loc,
+		Median:      median(sorted),
+		Min:         sorted[0],
+		Max:         sorted[len(sorted)-1],
+		Samples:     DefaultViolinSamples,
+		Width:       w,
+		FillColor:   color.Gray{Y: 200},
+		LineStyle:   DefaultLineStyle,
+		ShowMedian:  true,
+		MedianStyle: DefaultLineStyle,
+	}, nil
+}
+
+// bandwidth returns the bandwidth of the kernel density
+// estimate, using Silverman's rule of thumb if the
+// Bandwidth field is not set.
+func (v *Violin) bandwidth() float64 {
+	if v.Bandwidth > 0 {
+		return v.Bandwidth
+	}
+	n := float64(len(v.Values))
+	var mean float64
+	for _, x := range v.Values {
+		mean += x
+	}
+	mean /= n
+	var ss float64
+	for _, x := range v.Values {
+		ss += (x - mean) * (x - mean)
+	}
+	sd := 0.0
+	if n > 1 {
+		sd = math.Sqrt(ss / (n - 1))
+	}
+
+	sorted := make(Values, len(v.Values))
+	copy(sorted, v.Values)
+	sort.Float64s(sorted)
+	spread := sd
+	if len(sorted) > 1 {
+		iqr := median(sorted[len(sorted)/2:]) - median(sorted[:len(sorted)/2])
+		if iqr > 0 && iqr/1.34 < spread {
+			spread = iqr / 1.34
+		}
+	}
+	if spread == 0 {
+		return 1
+	}
+	return 0.9 * spread * math.Pow(n, -0.2)
+}
+
+// density returns the kernel density estimate
+// of the values at x using bandwidth h.
+func (v *Violin) density(x, h float64) float64 {
+	var sum float64
+	for _, xi := range v.Values {
+		u := (x - xi) / h
+		sum += math.Exp(-u * u / 2)
+	}
+	return sum / (float64(len(v.Values)) * h * math.Sqrt(2*math.Pi))
+}
+
+// shape returns the values at which the density is
+// evaluated and the half-widths of the violin at
+// those values, along with the half-width function.
+func (v *Violin) shape() (ys []float64, ws []vg.Length, width func(float64) vg.Length) {
+	n := v.Samples
+	if n < 2 {
+		n = 2
+	}
+	h := v.bandwidth()
+	ys = make([]float64, n)
+	ds := make([]float64, n)
+	var max float64
+	for i := range ys {
+		ys[i] = v.Min + (v.Max-v.Min)*float64(i)/float64(n-1)
+		ds[i] = v.density(ys[i], h)
+		max = math.Max(max, ds[i])
+	}
+	width = func(y float64) vg.Length {
+		if max == 0 {
+			return 0
+		}
+		return v.Width / 2 * vg.Length(v.density(y, h)/max)
+	}
+	ws = make([]vg.Length, n)
+	for i, d := range ds {
+		if max > 0 {
+			ws[i] = v.Width / 2 * vg.Length(d/max)
+		}
+	}
+	return ys, ws, width
+}
+
+// Plot draws the Violin on Canvas c and Plot plt.
+func (v *Violin) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	trLoc, trVal := trX, trY
+	if v.Horizontal {
+		trLoc, trVal = trY, trX
+	}
+	loc := trLoc(v.Location)
+	if v.Horizontal && !c.ContainsY(loc) || !v.Horizontal && !c.ContainsX(loc) {
+		return
+	}
+	loc += v.Offset
+
+	// pt returns the point at distance d from the
+	// center of the violin at the value position p.
+	pt := func(d, p vg.Length) vg.Point {
+		if v.Horizontal {
+			return vg.Point{X: p, Y: loc + d}
+		}
+		return vg.Point{X: loc + d, Y: p}
+	}
+
+	ys, ws, width := v.shape()
+	pts := make([]vg.Point, 0, 2*len(ys)+1)
+	for i, y := range ys {
+		pts = append(pts, pt(ws[i], trVal(y)))
+	}
+	for i := len(ys) - 1; i >= 0; i-- {
+		pts = append(pts, pt(-ws[i], trVal(ys[i])))
+	}
+
+	if v.Horizontal {
+		if v.FillColor != nil {
+			c.FillPolygon(v.FillColor, c.ClipPolygonX(pts))
+		}
+		pts = append(pts, pts[0])
+		c.StrokeLines(v.LineStyle, c.ClipLinesX(pts)...)
+	} else {
+		if v.FillColor != nil {
+			c.FillPolygon(v.FillColor, c.ClipPolygonY(pts))
+		}
+		pts = append(pts, pts[0])
+		c.StrokeLines(v.LineStyle, c.ClipLinesY(pts)...)
+	}
+
+	if !v.ShowMedian {
+		return
+	}
+	med := trVal(v.Median)
+	w := width(v.Median)
+	medLine := []vg.Point{pt(-w, med), pt(w, med)}
+	if v.Horizontal {
+		c.StrokeLines(v.MedianStyle, c.ClipLinesX(medLine)...)
+	} else {
+		c.StrokeLines(v.MedianStyle, c.ClipLinesY(medLine)...)
+	}
+}
+
+// DataRange returns the minimum and maximum x
+// and y values, implementing the plot.DataRanger
+// interface.
+func (v *Violin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if v.Horizontal {
+		return v.Min, v.Max, v.Location, v.Location
+	}
+	return v.Location, v.Location, v.Min, v.Max
+}
+
+// GlyphBoxes returns a GlyphBox covering the width
+// of the violin at its median, implementing the
+// plot.GlyphBoxer interface.
+func (v *Violin) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	half := v.Width/2 + v.LineStyle.Width/2
+	if v.Horizontal {
+		return []plot.GlyphBox{{
+			X: plt.X.Norm(v.Median),
+			Y: plt.Y.Norm(v.Location),
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{Y: v.Offset - half},
+				Max: vg.Point{Y: v.Offset + half},
+			},
+		}}
+	}
+	return []plot.GlyphBox{{
+		X: plt.X.Norm(v.Location),
+		Y: plt.Y.Norm(v.Median),
+		Rectangle: vg.Rectangle{
+			Min: vg.Point{X: v.Offset - half},
+			Max: vg.Point{X: v.Offset + half},
+		},
+	}}
+}
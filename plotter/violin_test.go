@@ -0,0 +1,110 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/internal/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+// ExampleViolin draws violin plots of three
+// distributions on a nominal X axis.
+func ExampleViolin() {
+	rnd := rand.New(rand.NewSource(1))
+
+	n := 100
+	uniform := make(Values, n)
+	normal := make(Values, n)
+	bimodal := make(Values, n)
+	for i := 0; i < n; i++ {
+		uniform[i] = rnd.Float64()
+		normal[i] = rnd.NormFloat64()
+		bimodal[i] = rnd.NormFloat64()/2 + float64(2*(i%2)) - 1
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Violin Plots"
+	p.Y.Label.Text = "Value"
+
+	for i, vs := range []Values{uniform, normal, bimodal} {
+		v, err := NewViolin(vg.Points(40), float64(i), vs)
+		if err != nil {
+			log.Panic(err)
+		}
+		p.Add(v)
+	}
+	p.NominalX("Uniform", "Normal", "Bimodal")
+
+	err = p.Save(200, 200, "testdata/violin.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestViolin(t *testing.T) {
+	cmpimg.CheckPlot(ExampleViolin, t, "violin.png")
+}
+
+func TestViolinDensity(t *testing.T) {
+	v, err := NewViolin(vg.Points(20), 1, Values{1, 2, 3, 4, 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Median != 3 {
+		t.Errorf("unexpected median: got:%v want:3", v.Median)
+	}
+	xmin, xmax, ymin, ymax := v.DataRange()
+	if xmin != 1 || xmax != 1 || ymin != 1 || ymax != 10 {
+		t.Errorf("unexpected data range: got:[%v,%v]x[%v,%v] want:[1,1]x[1,10]", xmin, xmax, ymin, ymax)
+	}
+
+	// The density of a single value is the Gaussian kernel.
+	single := &Violin{Values: Values{0}}
+	for _, x := range []float64{0, 0.5, 2} {
+		got := single.density(x, 0.5)
+		want := math.Exp(-x*x/(2*0.5*0.5)) / (0.5 * math.Sqrt(2*math.Pi))
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("unexpected density at %v: got:%v want:%v", x, got, want)
+		}
+	}
+
+	v.Bandwidth = 0.25
+	if got := v.bandwidth(); got != 0.25 {
+		t.Errorf("unexpected bandwidth: got:%v want:0.25", got)
+	}
+
+	_, ws, _ := v.shape()
+	var widest vg.Length
+	for _, w := range ws {
+		if w > widest {
+			widest = w
+		}
+	}
+	if widest != v.Width/2 {
+		t.Errorf("unexpected maximum half-width: got:%v want:%v", widest, v.Width/2)
+	}
+
+	v.Horizontal = true
+	xmin, xmax, ymin, ymax = v.DataRange()
+	if xmin != 1 || xmax != 10 || ymin != 1 || ymax != 1 {
+		t.Errorf("unexpected horizontal data range: got:[%v,%v]x[%v,%v] want:[1,10]x[1,1]", xmin, xmax, ymin, ymax)
+	}
+
+	if _, err := NewViolin(vg.Points(20), 0, Values{}); err == nil {
+		t.Error("expected error for empty values")
+	}
+	if _, err := NewViolin(-1, 0, Values{1}); err == nil {
+		t.Error("expected error for negative width")
+	}
+}
@@ -6,15 +6,13 @@ package draw // import "gonum.org/v1/plot/vg/draw"
 
 import (
 	"fmt"
+	"image"
 	"image/color"
+	imgdraw "image/draw"
 	"math"
 	"strings"
 
 	"gonum.org/v1/plot/vg"
-	"gonum.org/v1/plot/vg/vgeps"
-	"gonum.org/v1/plot/vg/vgimg"
-	"gonum.org/v1/plot/vg/vgpdf"
-	"gonum.org/v1/plot/vg/vgsvg"
 )
 
 // A Canvas is a vector graphics canvas along with
@@ -23,6 +21,11 @@ import (
 type Canvas struct {
 	vg.Canvas
 	vg.Rectangle
+
+	// transparency is one minus the opacity
+	// set by SetAlpha, so that drawing to the
+	// zero Canvas is opaque.
+	transparency float64
 }
 
 // TextStyle describes what text will look like.
@@ -257,6 +260,70 @@ func (CrossGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
 	c.Stroke(p)
 }
 
+// OutlinedGlyph is a glyph that draws the filled parts of
+// the glyph drawn by Shape filled with FillColor and
+// outlined in the color of the glyph style, allowing
+// markers with a fill color that differs from their
+// outline and hollow markers.  The parts of Shape that
+// are stroked are drawn unchanged.
+type OutlinedGlyph struct {
+	// Shape draws the shape of the glyph.
+	// If Shape is nil, CircleGlyph is used.
+	Shape GlyphDrawer
+
+	// FillColor is the color used to fill the glyph.
+	// If FillColor is nil, the glyph is hollow.
+	FillColor color.Color
+
+	// OutlineWidth is the width of the outline.
+	// If OutlineWidth is zero, no outline is drawn.
+	OutlineWidth vg.Length
+}
+
+// DrawGlyph implements the Glyph interface.
+func (g OutlinedGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	shape := g.Shape
+	if shape == nil {
+		shape = CircleGlyph{}
+	}
+	oc := Canvas{
+		Canvas: outlineCanvas{
+			Canvas: *c,
+			fill:   g.FillColor,
+			line:   LineStyle{Color: sty.Color, Width: g.OutlineWidth},
+		},
+		Rectangle: c.Rectangle,
+	}
+	shape.DrawGlyph(&oc, sty, pt)
+}
+
+// outlineCanvas is a vg.Canvas that replaces filling
+// with filling in the fill color and stroking with the
+// line style, used to draw an OutlinedGlyph.
+type outlineCanvas struct {
+	vg.Canvas
+	fill color.Color
+	line LineStyle
+}
+
+// Fill fills the path with the fill color, if it is not
+// nil, and strokes it with the line style, if its width
+// is positive.
+func (c outlineCanvas) Fill(p vg.Path) {
+	c.Push()
+	defer c.Pop()
+	if c.fill != nil {
+		c.SetColor(c.fill)
+		c.Canvas.Fill(p)
+	}
+	if c.line.Width > 0 {
+		c.SetColor(c.line.Color)
+		c.SetLineWidth(c.line.Width)
+		c.SetLineDash(c.line.Dashes, c.line.DashOffs)
+		c.Canvas.Stroke(p)
+	}
+}
+
 // New returns a new (bounded) draw.Canvas.
 func New(c vg.CanvasSizer) Canvas {
 	w, h := c.Size()
@@ -264,36 +331,29 @@ func New(c vg.CanvasSizer) Canvas {
 }
 
 // NewFormattedCanvas creates a new vg.CanvasWriterTo with the specified
-// image format.
+// image format.  Raster image canvases are initially white.
 //
-// Supported formats are:
+// The built-in formats are:
 //
-//  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
+//  eps, jpg|jpeg, pdf, png, ps, svg, and tif|tiff.
+//
+// Other formats may be added with RegisterFormat.
 func NewFormattedCanvas(w, h vg.Length, format string) (vg.CanvasWriterTo, error) {
-	var c vg.CanvasWriterTo
-	switch format {
-	case "eps":
-		c = vgeps.New(w, h)
-
-	case "jpg", "jpeg":
-		c = vgimg.JpegCanvas{Canvas: vgimg.New(w, h)}
-
-	case "pdf":
-		c = vgpdf.New(w, h)
-
-	case "png":
-		c = vgimg.PngCanvas{Canvas: vgimg.New(w, h)}
-
-	case "svg":
-		c = vgsvg.New(w, h)
-
-	case "tif", "tiff":
-		c = vgimg.TiffCanvas{Canvas: vgimg.New(w, h)}
-
-	default:
+	return NewFormattedCanvasBackground(w, h, format, color.White)
+}
+
+// NewFormattedCanvasBackground is like NewFormattedCanvas, but
+// raster image canvases are initially filled with the given
+// background color.  If bg is nil then png and tif|tiff
+// canvases are initially transparent, and jpg|jpeg canvases,
+// which do not support transparency, are initially white.
+// Vector image canvases are always initially transparent.
+func NewFormattedCanvasBackground(w, h vg.Length, format string, bg color.Color) (vg.CanvasWriterTo, error) {
+	fn, ok := formatFunc(format)
+	if !ok {
 		return nil, fmt.Errorf("unsupported format: %q", format)
 	}
-	return c, nil
+	return fn(w, h, bg), nil
 }
 
 // NewCanvas returns a new (bounded) draw.Canvas of the given size.
@@ -404,6 +464,75 @@ func (ts Tiles) At(c Canvas, x, y int) Canvas {
 	}
 }
 
+// SetAlpha sets a global opacity, in the range [0, 1], that is
+// multiplied into the alpha of every color subsequently set on
+// the Canvas, including colors that are already translucent,
+// and of every image drawn to it.  The opacity belongs to the
+// Canvas value: canvases derived from c, for example by Crop,
+// draw through c and so are also affected by the opacity c had
+// when they were derived, but later calls to SetAlpha on either
+// canvas do not affect the other.  Calling SetAlpha with an
+// alpha of 1 restores fully opaque drawing.
+func (c *Canvas) SetAlpha(alpha float64) {
+	c.transparency = 1 - math.Max(0, math.Min(1, alpha))
+}
+
+// SetColor sets the current drawing color of the
+// underlying vg.Canvas, scaling its alpha by the
+// opacity set with SetAlpha.
+func (c Canvas) SetColor(clr color.Color) {
+	if c.transparency == 0 {
+		c.Canvas.SetColor(clr)
+		return
+	}
+	if clr == nil {
+		clr = color.Black
+	}
+	alpha := 1 - c.transparency
+	r, g, b, a := clr.RGBA()
+	c.Canvas.SetColor(color.RGBA64{
+		R: uint16(float64(r) * alpha),
+		G: uint16(float64(g) * alpha),
+		B: uint16(float64(b) * alpha),
+		A: uint16(float64(a) * alpha),
+	})
+}
+
+// DrawImage draws the image to the underlying vg.Canvas,
+// scaling its alpha by the opacity set with SetAlpha.
+func (c Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
+	if c.transparency == 0 {
+		c.Canvas.DrawImage(rect, img)
+		return
+	}
+	b := img.Bounds()
+	faded := image.NewRGBA(b)
+	mask := image.NewUniform(color.Alpha16{A: uint16((1 - c.transparency) * 0xffff)})
+	imgdraw.DrawMask(faded, b, img, b.Min, mask, image.ZP, imgdraw.Src)
+	c.Canvas.DrawImage(rect, faded)
+}
+
+// backend returns the vg.Canvas that vc ultimately draws
+// to, unwrapping the Canvases that nest it, for example
+// by Crop or the padding of a plot's data area, and the
+// canvas used to draw an OutlinedGlyph.  Optional
+// interfaces of the backend, such as vg.Clipper, must be
+// found on the canvas that backend returns.
+func backend(vc vg.Canvas) vg.Canvas {
+	for {
+		switch dc := vc.(type) {
+		case Canvas:
+			vc = dc.Canvas
+		case *Canvas:
+			vc = dc.Canvas
+		case outlineCanvas:
+			vc = dc.Canvas
+		default:
+			return vc
+		}
+	}
+}
+
 // SetLineStyle sets the current line style
 func (c *Canvas) SetLineStyle(sty LineStyle) {
 	c.SetColor(sty.Color)
@@ -415,26 +544,67 @@ func (c *Canvas) SetLineStyle(sty LineStyle) {
 	c.SetLineDash(dashDots, sty.DashOffs)
 }
 
-// StrokeLines draws a line connecting a set of points
-// in the given Canvas.
+// Clip restricts subsequent drawing to the inside of the
+// path until the Pop corresponding to the most recent Push,
+// if the underlying vg.Canvas implements vg.Clipper, and
+// returns whether it does.  Drawing to other canvases is
+// not clipped.
+func (c *Canvas) Clip(p vg.Path) bool {
+	cl, ok := backend(c.Canvas).(vg.Clipper)
+	if ok {
+		cl.Clip(p)
+	}
+	return ok
+}
+
+// Tag attaches the id and the link target href, either of
+// which may be empty, to the next element drawn to the Canvas,
+// if the underlying vg.Canvas implements vg.Tagger, and returns
+// whether it does.  This makes, for example, the regions drawn
+// by a plotter clickable in SVG output.  Drawing to other
+// canvases is unaffected.
+func (c *Canvas) Tag(id, href string) bool {
+	tg, ok := backend(c.Canvas).(vg.Tagger)
+	if ok {
+		tg.Tag(id, href)
+	}
+	return ok
+}
+
+// WithState calls f with c after saving the graphics state
+// of the underlying vg.Canvas with Push, and restores the
+// state with the corresponding Pop when f returns, even if
+// f panics.  Changes that f makes to the color, line width,
+// line dashes or transformation, and any clipping by Clip,
+// do not affect drawing after WithState returns.
+func (c *Canvas) WithState(f func(c *Canvas)) {
+	c.Push()
+	defer c.Pop()
+	f(c)
+}
+
+// StrokeLines draws a line connecting each set of points
+// in the given Canvas.  All of the lines are stroked as a
+// single path, so the style, including any dashes, is set
+// only once and the underlying vg.Canvas is called once
+// for the whole set of lines.
 func (c *Canvas) StrokeLines(sty LineStyle, lines ...[]vg.Point) {
-	if len(lines) == 0 {
-		return
-	}
-
-	c.SetLineStyle(sty)
-
+	var p vg.Path
 	for _, l := range lines {
 		if len(l) == 0 {
 			continue
 		}
-		var p vg.Path
 		p.Move(l[0])
 		for _, pt := range l[1:] {
 			p.Line(pt)
 		}
-		c.Stroke(p)
 	}
+	if len(p) == 0 {
+		return
+	}
+
+	c.SetLineStyle(sty)
+	c.Stroke(p)
 }
 
 // StrokeLine2 draws a line between two points in the given
@@ -516,6 +686,8 @@ func clipLine(in func(vg.Point, vg.Point) bool, clip, norm vg.Point, pts []vg.Po
 }
 
 // FillPolygon fills a polygon with the given color.
+// Self-intersecting polygons are filled using the
+// nonzero winding rule.
 func (c *Canvas) FillPolygon(clr color.Color, pts []vg.Point) {
 	if len(pts) == 0 {
 		return
@@ -644,6 +816,87 @@ func (c *Canvas) FillText(sty TextStyle, pt vg.Point, txt string) {
 	}
 }
 
+// FillTextRotated fills lines of text in the draw area,
+// rotated by angle radians counter-clockwise around pt,
+// with the text aligned relative to pt by xalign and
+// yalign in the rotated frame.  It is equivalent to
+// FillText with the XAlign, YAlign and Rotation of the
+// style set to xalign, yalign and angle.
+func (c *Canvas) FillTextRotated(sty TextStyle, pt vg.Point, xalign XAlignment, yalign YAlignment, angle float64, txt string) {
+	sty.XAlign = xalign
+	sty.YAlign = yalign
+	sty.Rotation = angle
+	c.FillText(sty, pt, txt)
+}
+
+// FillTextPath fills a line of text along a path in the
+// draw area, rotating each character to follow the
+// direction of the path at the character's position.
+// Characters are spaced by their advance widths in the
+// font.  The XAlign of the style positions the text
+// along the path, and the YAlign positions it across
+// the path; the style's Rotation is ignored.  Text that
+// extends past the end of the path continues in the
+// direction of the path's first or last segment.
+func (c *Canvas) FillTextPath(sty TextStyle, path []vg.Point, txt string) {
+	txt = strings.Replace(txt, "\n", " ", -1)
+	switch len(path) {
+	case 0:
+		return
+	case 1:
+		sty.Rotation = 0
+		c.FillText(sty, path[0], txt)
+		return
+	}
+
+	// dist holds the distance along the path
+	// to each of its points.
+	dist := make([]vg.Length, len(path))
+	for i := 1; i < len(path); i++ {
+		dist[i] = dist[i-1] + segmentLength(path[i-1], path[i])
+	}
+	length := dist[len(dist)-1]
+
+	s := vg.Length(-sty.XAlign) * (length - sty.Font.Width(txt))
+	glyph := sty
+	glyph.XAlign = XCenter
+	for _, r := range txt {
+		ch := string(r)
+		w := sty.Font.Width(ch)
+		pt, angle := pointAlong(path, dist, s+w/2)
+		glyph.Rotation = angle
+		c.FillText(glyph, pt, ch)
+		s += w
+	}
+}
+
+// segmentLength returns the length of the
+// line segment from p to q.
+func segmentLength(p, q vg.Point) vg.Length {
+	d := q.Sub(p)
+	return vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+}
+
+// pointAlong returns the point at distance s along the
+// path, and the direction of the path at that point,
+// where dist holds the distance along the path to each
+// of its points.  Distances beyond the ends of the path
+// are extended along its first or last segment.
+func pointAlong(path []vg.Point, dist []vg.Length, s vg.Length) (vg.Point, float64) {
+	i := 1
+	for i < len(path)-1 && dist[i] < s {
+		i++
+	}
+	p0, p1 := path[i-1], path[i]
+	d := p1.Sub(p0)
+	angle := math.Atan2(float64(d.Y), float64(d.X))
+	seg := dist[i] - dist[i-1]
+	if seg == 0 {
+		return p0, angle
+	}
+	return p0.Add(d.Scale((s - dist[i-1]) / seg)), angle
+}
+
 // rotatePoint applies rotation theta (in radians) about the origin to point p.
 func rotatePoint(theta float64, p vg.Point) vg.Point {
 	if theta == 0 {
@@ -1,8 +1,11 @@
 package draw
 
 import (
+	"image"
 	"image/color"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
 
 	"gonum.org/v1/plot/vg"
@@ -111,3 +114,341 @@ func TestTile(t *testing.T) {
 		}
 	}
 }
+
+func TestSetAlpha(t *testing.T) {
+	var r recorder.Canvas
+	c := NewCanvas(&r, 6, 3)
+	c.SetAlpha(0.5)
+	c.SetColor(color.NRGBA{R: 255, A: 255})
+	crop := Crop(c, 1, 0, 0, 0)
+	crop.SetColor(color.NRGBA{G: 255, A: 128})
+
+	// Changing the alpha of a canvas does not
+	// change that of canvases derived from it.
+	c.SetAlpha(1)
+	crop.SetColor(color.White)
+	c.SetColor(color.White)
+
+	crop.SetAlpha(0.5)
+	crop.SetColor(color.White)
+
+	want := []color.Color{
+		color.RGBA64{R: 0x7fff, A: 0x7fff},
+		color.RGBA64{G: 0x4040, A: 0x4040},
+		color.RGBA64{R: 0x7fff, G: 0x7fff, B: 0x7fff, A: 0x7fff},
+		color.White,
+		color.RGBA64{R: 0x3fff, G: 0x3fff, B: 0x3fff, A: 0x3fff},
+	}
+	var got []color.Color
+	for _, a := range r.Actions {
+		if sc, ok := a.(*recorder.SetColor); ok {
+			got = append(got, sc.Color)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected colors:\ngot: %#v\nwant:%#v", got, want)
+	}
+}
+
+func TestSetAlphaImage(t *testing.T) {
+	var r recorder.Canvas
+	c := NewCanvas(&r, 6, 3)
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.NRGBA{B: 255, A: 255})
+	c.DrawImage(c.Rectangle, img)
+	c.SetAlpha(0.5)
+	c.DrawImage(c.Rectangle, img)
+
+	want := []color.Color{
+		color.RGBA{B: 255, A: 255},
+		color.RGBA{B: 127, A: 127},
+	}
+	var got []color.Color
+	for _, a := range r.Actions {
+		if di, ok := a.(*recorder.DrawImage); ok {
+			got = append(got, di.Image.At(0, 0))
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected image colors:\ngot: %#v\nwant:%#v", got, want)
+	}
+}
+
+func TestSetAlphaOutlinedGlyph(t *testing.T) {
+	var r recorder.Canvas
+	c := NewCanvas(&r, 10, 10)
+	c.SetAlpha(0.5)
+	glyph := OutlinedGlyph{FillColor: color.NRGBA{R: 255, A: 255}, OutlineWidth: 1}
+	c.DrawGlyph(GlyphStyle{Color: color.NRGBA{B: 255, A: 255}, Radius: 2, Shape: glyph}, c.Center())
+
+	var col color.Color
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.SetColor:
+			col = a.Color
+		case *recorder.Fill:
+			if want := (color.RGBA64{R: 0x7fff, A: 0x7fff}); col != want {
+				t.Errorf("unexpected fill color: got:%v want:%v", col, want)
+			}
+		case *recorder.Stroke:
+			if want := (color.RGBA64{B: 0x7fff, A: 0x7fff}); col != want {
+				t.Errorf("unexpected stroke color: got:%v want:%v", col, want)
+			}
+		}
+	}
+}
+
+func TestWithState(t *testing.T) {
+	var r recorder.Canvas
+	c := NewCanvas(&r, 6, 3)
+	c.SetColor(color.White)
+	c.WithState(func(c *Canvas) {
+		c.SetLineWidth(2)
+		c.SetColor(color.Black)
+	})
+
+	var got []string
+	for _, a := range r.Actions {
+		got = append(got, reflect.TypeOf(a).Elem().Name())
+	}
+	want := []string{"SetColor", "Push", "SetLineWidth", "SetColor", "Pop"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected actions:\ngot: %v\nwant:%v", got, want)
+	}
+
+	func() {
+		defer func() { recover() }()
+		c.WithState(func(*Canvas) { panic("fail") })
+	}()
+	if _, ok := r.Actions[len(r.Actions)-1].(*recorder.Pop); !ok {
+		t.Errorf("state not restored after panic")
+	}
+}
+
+func TestFillTextPath(t *testing.T) {
+	font, err := vg.MakeFont("Times-Roman", 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sty := TextStyle{Color: color.Black, Font: font}
+
+	var r recorder.Canvas
+	c := NewCanvas(&r, 100, 100)
+	path := []vg.Point{{X: 10, Y: 10}, {X: 50, Y: 10}, {X: 50, Y: 90}}
+	c.FillTextPath(sty, path, "abcdefgh")
+
+	var (
+		strs    []string
+		rotates []float64
+	)
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.FillString:
+			strs = append(strs, a.String)
+		case *recorder.Rotate:
+			rotates = append(rotates, a.Angle)
+		}
+	}
+	if got := strings.Join(strs, ""); got != "abcdefgh" {
+		t.Errorf("unexpected text: got:%q want:%q", got, "abcdefgh")
+	}
+	// The text is longer than the first segment, so some
+	// of the characters are rotated to follow the second.
+	if len(rotates) == 0 {
+		t.Fatal("no characters rotated to follow the path")
+	}
+	for _, got := range rotates {
+		if got != math.Pi/2 {
+			t.Errorf("unexpected character rotation: got:%v want:%v", got, math.Pi/2)
+		}
+	}
+	if len(rotates) >= len(strs) {
+		t.Errorf("all characters rotated: got %d rotations for %d characters", len(rotates), len(strs))
+	}
+}
+
+func TestFillTextRotated(t *testing.T) {
+	font, err := vg.MakeFont("Times-Roman", 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sty := TextStyle{Color: color.Black, Font: font}
+	pt := vg.Point{X: 40, Y: 60}
+
+	var got recorder.Canvas
+	c := NewCanvas(&got, 100, 100)
+	c.FillTextRotated(sty, pt, XCenter, YBottom, math.Pi/6, "slope")
+
+	var want recorder.Canvas
+	c = NewCanvas(&want, 100, 100)
+	sty.XAlign = XCenter
+	sty.YAlign = YBottom
+	sty.Rotation = math.Pi / 6
+	c.FillText(sty, pt, "slope")
+
+	if len(got.Actions) != len(want.Actions) {
+		t.Fatalf("unexpected number of actions: got:%d want:%d", len(got.Actions), len(want.Actions))
+	}
+	var rotated bool
+	for i, a := range got.Actions {
+		if a.Call() != want.Actions[i].Call() {
+			t.Errorf("unexpected action %d: got:%s want:%s", i, a.Call(), want.Actions[i].Call())
+		}
+		if r, ok := a.(*recorder.Rotate); ok && r.Angle == math.Pi/6 {
+			rotated = true
+		}
+	}
+	if !rotated {
+		t.Error("text not rotated")
+	}
+}
+
+func TestStrokeLines(t *testing.T) {
+	sty := LineStyle{Color: color.Black, Width: 1, Dashes: []vg.Length{2, 1}}
+	lines := [][]vg.Point{
+		{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 20, Y: 0}},
+		nil,
+		{{X: 0, Y: 20}, {X: 20, Y: 20}},
+	}
+
+	var r recorder.Canvas
+	c := NewCanvas(&r, 100, 100)
+	c.StrokeLines(sty, lines...)
+
+	var strokes []*recorder.Stroke
+	var dashed bool
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.Stroke:
+			strokes = append(strokes, a)
+		case *recorder.SetLineDash:
+			dashed = reflect.DeepEqual(a.Dashes, sty.Dashes)
+		}
+	}
+	if len(strokes) != 1 {
+		t.Fatalf("unexpected number of strokes: got:%d want:1", len(strokes))
+	}
+	if !dashed {
+		t.Error("line dashes not set")
+	}
+	var moves, segs int
+	for _, comp := range strokes[0].Path {
+		switch comp.Type {
+		case vg.MoveComp:
+			moves++
+		case vg.LineComp:
+			segs++
+		}
+	}
+	if moves != 2 || segs != 3 {
+		t.Errorf("unexpected path: got:%d moves %d lines want:2 moves 3 lines", moves, segs)
+	}
+
+	r.Reset()
+	c.StrokeLines(sty)
+	c.StrokeLines(sty, nil)
+	if len(r.Actions) != 0 {
+		t.Errorf("unexpected actions for no lines: %d", len(r.Actions))
+	}
+}
+
+func TestHatchPolygon(t *testing.T) {
+	square := []vg.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	sty := HatchStyle{
+		LineStyle: LineStyle{Color: color.Black, Width: 1},
+		Spacing:   2,
+	}
+
+	strokes := func(sty HatchStyle) []vg.Path {
+		var r recorder.Canvas
+		c := NewCanvas(&r, 100, 100)
+		c.HatchPolygon(sty, square)
+		// Split the stroked paths into their subpaths.
+		var paths []vg.Path
+		for _, a := range r.Actions {
+			s, ok := a.(*recorder.Stroke)
+			if !ok {
+				continue
+			}
+			for _, comp := range s.Path {
+				if comp.Type == vg.MoveComp {
+					paths = append(paths, nil)
+				}
+				paths[len(paths)-1] = append(paths[len(paths)-1], comp)
+			}
+		}
+		return paths
+	}
+
+	got := strokes(sty)
+	// Horizontal lines at y = 2, 4, 6 and 8; the lines at
+	// y = 0 and y = 10 lie on the boundary.
+	if len(got) != 4 {
+		t.Fatalf("unexpected number of hatch lines: got:%d want:4", len(got))
+	}
+	for _, p := range got {
+		if p[0].Pos.X != 0 || p[1].Pos.X != 10 || p[0].Pos.Y != p[1].Pos.Y {
+			t.Errorf("unexpected hatch line: got:%v", p)
+		}
+	}
+
+	sty.Cross = true
+	if got := len(strokes(sty)); got != 8 {
+		t.Errorf("unexpected number of cross hatch lines: got:%d want:8", got)
+	}
+
+	sty.Cross = false
+	sty.Angle = math.Pi / 4
+	for _, p := range strokes(sty) {
+		for _, c := range p {
+			if c.Pos.X < -1e-9 || c.Pos.X > 10+1e-9 || c.Pos.Y < -1e-9 || c.Pos.Y > 10+1e-9 {
+				t.Errorf("diagonal hatch line outside polygon: got:%v", p)
+			}
+		}
+		d := p[1].Pos.Sub(p[0].Pos)
+		if math.Abs(float64(d.X-d.Y)) > 1e-9 {
+			t.Errorf("hatch line not at 45°: got:%v", p)
+		}
+	}
+}
+
+func TestOutlinedGlyph(t *testing.T) {
+	fill := color.NRGBA{R: 255, A: 255}
+	line := color.NRGBA{B: 255, A: 255}
+	for _, test := range []struct {
+		glyph          OutlinedGlyph
+		fills, strokes int
+	}{
+		{glyph: OutlinedGlyph{FillColor: fill, OutlineWidth: 1}, fills: 1, strokes: 1},
+		{glyph: OutlinedGlyph{Shape: SquareGlyph{}, OutlineWidth: 1}, fills: 0, strokes: 1},
+		{glyph: OutlinedGlyph{FillColor: fill}, fills: 1, strokes: 0},
+		{glyph: OutlinedGlyph{Shape: CrossGlyph{}, FillColor: fill, OutlineWidth: 1}, fills: 0, strokes: 2},
+	} {
+		var r recorder.Canvas
+		c := NewCanvas(&r, 10, 10)
+		c.DrawGlyph(GlyphStyle{Color: line, Radius: 2, Shape: test.glyph}, c.Center())
+
+		var fills, strokes int
+		var col color.Color
+		for _, a := range r.Actions {
+			switch a := a.(type) {
+			case *recorder.SetColor:
+				col = a.Color
+			case *recorder.Fill:
+				fills++
+				if col != fill {
+					t.Errorf("%+v: unexpected fill color: got:%v want:%v", test.glyph, col, fill)
+				}
+			case *recorder.Stroke:
+				strokes++
+				if col != line {
+					t.Errorf("%+v: unexpected stroke color: got:%v want:%v", test.glyph, col, line)
+				}
+			}
+		}
+		if fills != test.fills || strokes != test.strokes {
+			t.Errorf("%+v: unexpected number of fills and strokes: got:%d,%d want:%d,%d",
+				test.glyph, fills, strokes, test.fills, test.strokes)
+		}
+	}
+}
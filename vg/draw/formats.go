@@ -0,0 +1,125 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image/color"
+	"sort"
+	"sync"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/vgeps"
+	"gonum.org/v1/plot/vg/vgimg"
+	"gonum.org/v1/plot/vg/vgpdf"
+	"gonum.org/v1/plot/vg/vgsvg"
+)
+
+// FormatFunc returns a new canvas of the given size
+// that writes an image in some format.  Raster image
+// canvases should be initially filled with the
+// background color bg, or be transparent if bg is nil.
+type FormatFunc func(w, h vg.Length, bg color.Color) vg.CanvasWriterTo
+
+// format is a registered image format.
+type format struct {
+	mime string
+	fn   FormatFunc
+}
+
+var formats = struct {
+	sync.RWMutex
+	m map[string]format
+}{m: make(map[string]format)}
+
+// RegisterFormat registers a new image format for use
+// by NewFormattedCanvas and NewFormattedCanvasBackground,
+// and so by plot.Plot's Save, WriterTo and DataURI methods.
+// Name is the format name, which plot.Plot.Save matches
+// with the lower case extension of the file name, not
+// including the dot, and mimeType is the MIME type of the
+// images written in the format, such as "image/png".  If
+// the format is already registered, including if it is one
+// of the built-in formats, its MIME type and FormatFunc are
+// replaced.  RegisterFormat is safe for concurrent use.
+func RegisterFormat(name, mimeType string, fn FormatFunc) {
+	formats.Lock()
+	formats.m[name] = format{mime: mimeType, fn: fn}
+	formats.Unlock()
+}
+
+// Formats returns the sorted names of the
+// registered image formats.
+func Formats() []string {
+	formats.RLock()
+	names := make([]string, 0, len(formats.m))
+	for name := range formats.m {
+		names = append(names, name)
+	}
+	formats.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// MIMEType returns the MIME type of the named
+// image format, and whether it is registered.
+func MIMEType(name string) (string, bool) {
+	formats.RLock()
+	f, ok := formats.m[name]
+	formats.RUnlock()
+	return f.mime, ok
+}
+
+// formatFunc returns the FormatFunc of the
+// named format, and whether it is registered.
+func formatFunc(name string) (FormatFunc, bool) {
+	formats.RLock()
+	f, ok := formats.m[name]
+	formats.RUnlock()
+	return f.fn, ok
+}
+
+func init() {
+	eps := func(w, h vg.Length, _ color.Color) vg.CanvasWriterTo {
+		return vgeps.New(w, h)
+	}
+	RegisterFormat("eps", "application/postscript", eps)
+	RegisterFormat("ps", "application/postscript", func(w, h vg.Length, _ color.Color) vg.CanvasWriterTo {
+		return vgeps.PSCanvas{Canvas: vgeps.New(w, h)}
+	})
+
+	jpeg := func(w, h vg.Length, bg color.Color) vg.CanvasWriterTo {
+		// jpg images do not support transparency.
+		if bg == nil {
+			bg = color.White
+		}
+		return vgimg.JpegCanvas{Canvas: newImage(w, h, bg)}
+	}
+	RegisterFormat("jpg", "image/jpeg", jpeg)
+	RegisterFormat("jpeg", "image/jpeg", jpeg)
+
+	RegisterFormat("pdf", "application/pdf", func(w, h vg.Length, _ color.Color) vg.CanvasWriterTo {
+		return vgpdf.New(w, h)
+	})
+
+	RegisterFormat("png", "image/png", func(w, h vg.Length, bg color.Color) vg.CanvasWriterTo {
+		return vgimg.PngCanvas{Canvas: newImage(w, h, bg)}
+	})
+
+	RegisterFormat("svg", "image/svg+xml", func(w, h vg.Length, _ color.Color) vg.CanvasWriterTo {
+		return vgsvg.New(w, h)
+	})
+
+	tiff := func(w, h vg.Length, bg color.Color) vg.CanvasWriterTo {
+		return vgimg.TiffCanvas{Canvas: newImage(w, h, bg)}
+	}
+	RegisterFormat("tif", "image/tiff", tiff)
+	RegisterFormat("tiff", "image/tiff", tiff)
+}
+
+// newImage returns a new image canvas filled with the
+// background color.
+func newImage(w, h vg.Length, bg color.Color) *vgimg.Canvas {
+	return vgimg.NewWith(vgimg.UseWH(w, h), vgimg.UseBackgroundColor(bg))
+}
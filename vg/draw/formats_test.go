@@ -0,0 +1,67 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+func TestRegisterFormat(t *testing.T) {
+	builtin := []string{"eps", "jpeg", "jpg", "pdf", "png", "ps", "svg", "tif", "tiff"}
+	if got := Formats(); !reflect.DeepEqual(got, builtin) {
+		t.Errorf("unexpected built-in formats: got:%v want:%v", got, builtin)
+	}
+
+	var gotBg color.Color
+	RegisterFormat("bespoke", "image/x-bespoke", func(w, h vg.Length, bg color.Color) vg.CanvasWriterTo {
+		gotBg = bg
+		return vgimg.PngCanvas{Canvas: vgimg.New(w, h)}
+	})
+	defer func() {
+		formats.Lock()
+		delete(formats.m, "bespoke")
+		formats.Unlock()
+	}()
+
+	c, err := NewFormattedCanvasBackground(10, 20, "bespoke", color.Black)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w, h := c.Size(); w != 10 || h != 20 {
+		t.Errorf("unexpected canvas size: got:%vx%v want:10x20", w, h)
+	}
+	if gotBg != color.Black {
+		t.Errorf("unexpected background color: got:%v want:%v", gotBg, color.Black)
+	}
+	if mime, ok := MIMEType("bespoke"); !ok || mime != "image/x-bespoke" {
+		t.Errorf("unexpected MIME type: got:%q want:%q", mime, "image/x-bespoke")
+	}
+
+	// Registering a built-in format replaces it.
+	png, _ := formatFunc("png")
+	defer RegisterFormat("png", "image/png", png)
+	var called bool
+	RegisterFormat("png", "image/png", func(w, h vg.Length, bg color.Color) vg.CanvasWriterTo {
+		called = true
+		return png(w, h, bg)
+	})
+	_, err = NewFormattedCanvas(10, 10, "png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("replacement png format not used")
+	}
+
+	_, err = NewFormattedCanvas(10, 10, "unknown")
+	if err == nil {
+		t.Error("expected error for unregistered format")
+	}
+}
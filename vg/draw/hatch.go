@@ -0,0 +1,106 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot/vg"
+)
+
+// HatchStyle describes a fill made of evenly spaced
+// parallel lines, which distinguishes regions without
+// relying on color.
+type HatchStyle struct {
+	// LineStyle is the style of the hatch lines.
+	LineStyle
+
+	// Angle is the angle of the hatch lines in
+	// radians, counterclockwise from horizontal.
+	Angle float64
+
+	// Spacing is the perpendicular distance
+	// between neighboring hatch lines.
+	Spacing vg.Length
+
+	// Cross specifies that a second set of lines
+	// perpendicular to the first is also drawn,
+	// giving a cross hatch.
+	Cross bool
+}
+
+// HatchPolygon fills a polygon with the hatch lines of the
+// given style.  The lines are clipped to the polygon using
+// the even-odd rule, so the hatch can be drawn by any
+// vg.Canvas, including those that do not support clipping
+// or patterns.  Nothing is drawn if Spacing is not positive.
+func (c *Canvas) HatchPolygon(sty HatchStyle, pts []vg.Point) {
+	if len(pts) < 3 || sty.Spacing <= 0 {
+		return
+	}
+	lines := hatchLines(pts, sty.Angle, sty.Spacing)
+	if sty.Cross {
+		lines = append(lines, hatchLines(pts, sty.Angle+math.Pi/2, sty.Spacing)...)
+	}
+	c.StrokeLines(sty.LineStyle, lines...)
+}
+
+// hatchLines returns the segments of parallel lines at the
+// given angle and spacing that lie within the polygon.
+func hatchLines(pts []vg.Point, angle float64, spacing vg.Length) [][]vg.Point {
+	sin, cos := math.Sincos(angle)
+
+	// Rotate the polygon by -angle so that the
+	// hatch lines are horizontal.
+	rot := make([]vg.Point, len(pts))
+	minY, maxY := vg.Length(math.Inf(1)), vg.Length(math.Inf(-1))
+	for i, p := range pts {
+		x, y := float64(p.X), float64(p.Y)
+		rot[i] = vg.Point{
+			X: vg.Length(x*cos + y*sin),
+			Y: vg.Length(-x*sin + y*cos),
+		}
+		if rot[i].Y < minY {
+			minY = rot[i].Y
+		}
+		if rot[i].Y > maxY {
+			maxY = rot[i].Y
+		}
+	}
+
+	// Lines are placed at multiples of the spacing so
+	// that the hatches of adjacent polygons line up.
+	// Lines that lie on the lowest or highest vertices,
+	// allowing for rounding in the rotation, are not
+	// drawn.
+	const tol = 1e-9
+	var lines [][]vg.Point
+	var xs []float64
+	start := vg.Length(math.Floor(float64(minY/spacing)+tol)+1) * spacing
+	for y := start; y < maxY-tol*spacing; y += spacing {
+		xs = xs[:0]
+		for i, p0 := range rot {
+			p1 := rot[(i+1)%len(rot)]
+			// Edges include their lower end point only, so
+			// that vertices are not counted twice.
+			if (p0.Y <= y) == (p1.Y <= y) {
+				continue
+			}
+			t := (y - p0.Y) / (p1.Y - p0.Y)
+			xs = append(xs, float64(p0.X+t*(p1.X-p0.X)))
+		}
+		sort.Float64s(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			x0, x1 := xs[i], xs[i+1]
+			yf := float64(y)
+			lines = append(lines, []vg.Point{
+				{X: vg.Length(x0*cos - yf*sin), Y: vg.Length(x0*sin + yf*cos)},
+				{X: vg.Length(x1*cos - yf*sin), Y: vg.Length(x1*sin + yf*cos)},
+			})
+		}
+	}
+	return lines
+}
@@ -4,6 +4,8 @@
 
 package vg
 
+import "math"
+
 // A Point is a location in 2d space.
 //
 // Points are used for drawing, not for data.  For
@@ -56,3 +58,33 @@ func (r Rectangle) Path() (p Path) {
 	p.Close()
 	return
 }
+
+// RoundedPath returns the path of a Rect with corners
+// rounded to the given radius.  The radius is limited to
+// half of the smaller of the Rect's width and height.  If
+// the radius is not positive then the path is the same as
+// the path returned by Path.
+func (r Rectangle) RoundedPath(radius Length) (p Path) {
+	size := r.Size()
+	lim := size.X / 2
+	if size.Y < size.X {
+		lim = size.Y / 2
+	}
+	if radius > lim {
+		radius = lim
+	}
+	if radius <= 0 {
+		return r.Path()
+	}
+	p.Move(Point{X: r.Min.X + radius, Y: r.Min.Y})
+	p.Line(Point{X: r.Max.X - radius, Y: r.Min.Y})
+	p.Arc(Point{X: r.Max.X - radius, Y: r.Min.Y + radius}, radius, -math.Pi/2, math.Pi/2)
+	p.Line(Point{X: r.Max.X, Y: r.Max.Y - radius})
+	p.Arc(Point{X: r.Max.X - radius, Y: r.Max.Y - radius}, radius, 0, math.Pi/2)
+	p.Line(Point{X: r.Min.X + radius, Y: r.Max.Y})
+	p.Arc(Point{X: r.Min.X + radius, Y: r.Max.Y - radius}, radius, math.Pi/2, math.Pi/2)
+	p.Line(Point{X: r.Min.X, Y: r.Min.Y + radius})
+	p.Arc(Point{X: r.Min.X + radius, Y: r.Min.Y + radius}, radius, math.Pi, math.Pi/2)
+	p.Close()
+	return
+}
@@ -0,0 +1,38 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoundedPath(t *testing.T) {
+	r := Rectangle{Min: Point{X: 1, Y: 2}, Max: Point{X: 11, Y: 6}}
+
+	for _, radius := range []Length{0, -1} {
+		if got, want := r.RoundedPath(radius), r.Path(); !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected path for radius %v:\ngot: %v\nwant:%v", radius, got, want)
+		}
+	}
+
+	p := r.RoundedPath(10)
+	var arcs int
+	for _, c := range p {
+		if c.Type != ArcComp {
+			continue
+		}
+		arcs++
+		if c.Radius != 2 {
+			t.Errorf("unexpected clamped arc radius: got:%v want:2", c.Radius)
+		}
+	}
+	if arcs != 4 {
+		t.Errorf("unexpected number of arcs: got:%d want:4", arcs)
+	}
+	if p[0].Type != MoveComp || p[len(p)-1].Type != CloseComp {
+		t.Errorf("path is not closed: %v", p)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import "testing"
+
+func TestPathCurves(t *testing.T) {
+	var p Path
+	p.Move(Point{X: 0, Y: 0})
+	p.QuadTo(Point{X: 1, Y: 2}, Point{X: 2, Y: 0})
+	p.CubeTo(Point{X: 3, Y: -2}, Point{X: 4, Y: 2}, Point{X: 5, Y: 0})
+
+	want := Path{
+		{Type: MoveComp, Pos: Point{X: 0, Y: 0}},
+		{Type: QuadComp, Pos: Point{X: 2, Y: 0}, Control: [2]Point{{X: 1, Y: 2}}},
+		{Type: CubeComp, Pos: Point{X: 5, Y: 0}, Control: [2]Point{{X: 3, Y: -2}, {X: 4, Y: 2}}},
+	}
+	if len(p) != len(want) {
+		t.Fatalf("unexpected path length: got:%d want:%d", len(p), len(want))
+	}
+	for i := range p {
+		if p[i] != want[i] {
+			t.Errorf("unexpected path component %d:\ngot: %#v\nwant:%#v", i, p[i], want[i])
+		}
+	}
+}
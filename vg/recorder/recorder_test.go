@@ -67,13 +67,13 @@ var want = []string{
 	`Comment("End of preamble")`,
 	`Scale(1, 2)`,
 	`Rotate(0.72)`,
-	`gonum.org/v1/plot/vg/recorder/recorder_test.go:23 Stroke(vg.Path{vg.PathComp{Type:0, Pos:vg.Point{X:3, Y:4}, Radius:0, Start:0, Angle:0}})`,
+	`gonum.org/v1/plot/vg/recorder/recorder_test.go:23 Stroke(vg.Path{vg.PathComp{Type:0, Pos:vg.Point{X:3, Y:4}, Radius:0, Start:0, Angle:0, Control:[2]vg.Point{vg.Point{X:0, Y:0}, vg.Point{X:0, Y:0}}}})`,
 	`gonum.org/v1/plot/vg/recorder/recorder_test.go:24 Push()`,
 	`gonum.org/v1/plot/vg/recorder/recorder_test.go:25 Pop()`,
 	`gonum.org/v1/plot/vg/recorder/recorder_test.go:26 Translate(3, 4)`,
 	`SetLineWidth(100)`,
 	`SetLineDash([]vg.Length{2, 5}, 6)`,
 	`SetColor(color.RGBA{R:0x65, G:0x23, B:0xf2, A:0x0})`,
-	`Fill(vg.Path{vg.PathComp{Type:0, Pos:vg.Point{X:3, Y:4}, Radius:0, Start:0, Angle:0}, vg.PathComp{Type:1, Pos:vg.Point{X:2, Y:3}, Radius:0, Start:0, Angle:0}, vg.PathComp{Type:3, Pos:vg.Point{X:0, Y:0}, Radius:0, Start:0, Angle:0}})`,
+	`Fill(vg.Path{vg.PathComp{Type:0, Pos:vg.Point{X:3, Y:4}, Radius:0, Start:0, Angle:0, Control:[2]vg.Point{vg.Point{X:0, Y:0}, vg.Point{X:0, Y:0}}}, vg.PathComp{Type:1, Pos:vg.Point{X:2, Y:3}, Radius:0, Start:0, Angle:0, Control:[2]vg.Point{vg.Point{X:0, Y:0}, vg.Point{X:0, Y:0}}}, vg.PathComp{Type:3, Pos:vg.Point{X:0, Y:0}, Radius:0, Start:0, Angle:0, Control:[2]vg.Point{vg.Point{X:0, Y:0}, vg.Point{X:0, Y:0}}}})`,
 	`DrawImage(vg.Rectangle{Min:vg.Point{X:0, Y:0}, Max:vg.Point{X:10, Y:10}}, {image.Rectangle{Min:image.Point{X:0, Y:0}, Max:image.Point{X:20, Y:20}}, IMAGE:iVBORw0KGgoAAAANSUhEUgAAABQAAAAUCAAAAACo4kLRAAAAFElEQVR4nGJiwAJGBQeVICAAAP//JBgAKeMueQ8AAAAASUVORK5CYII=})`,
 }
@@ -11,6 +11,7 @@ import (
 	"image"
 	"image/color"
 	"io"
+	"time"
 )
 
 // A Canvas is the main drawing interface for 2D vector
@@ -69,7 +70,10 @@ type Canvas interface {
 	// Stroke strokes the given path.
 	Stroke(Path)
 
-	// Fill fills the given path.
+	// Fill fills the given path.  Where the path
+	// intersects itself, or has several subpaths,
+	// the region filled is given by the nonzero
+	// winding rule.
 	Fill(Path)
 
 	// FillString fills in text at the specified
@@ -87,12 +91,67 @@ type CanvasSizer interface {
 	Size() (x, y Length)
 }
 
+// Clipper is a Canvas that can restrict drawing to
+// the inside of a path.
+type Clipper interface {
+	Canvas
+
+	// Clip restricts subsequent drawing to the inside
+	// of the given path, within any existing clipping
+	// region, using the nonzero winding rule.  The
+	// clipping region is part of the state saved by
+	// Push, so it is removed by the Pop corresponding
+	// to the most recent Push.  Clip must only be
+	// called after Push.
+	Clip(Path)
+}
+
+// Tagger is a Canvas that can attach an id and a link
+// to the elements it draws.
+type Tagger interface {
+	Canvas
+
+	// Tag attaches the id and the link target href,
+	// either of which may be empty, to the next
+	// element drawn by Stroke, Fill, FillString or
+	// DrawImage.
+	Tag(id, href string)
+}
+
 // CanvasWriterTo is a CanvasSizer with a WriteTo method.
 type CanvasWriterTo interface {
 	CanvasSizer
 	io.WriterTo
 }
 
+// Metadata is descriptive information about a drawing
+// that is recorded in the output of canvases that support
+// it.  Empty fields are not recorded.
+type Metadata struct {
+	// Title and Author are the title and
+	// the author of the document.
+	Title, Author string
+
+	// Subject is the subject of the document.
+	Subject string
+
+	// Keywords are keywords describing the document.
+	Keywords []string
+
+	// Created is the creation time of the document.
+	Created time.Time
+}
+
+// MetadataSetter is a Canvas that can record
+// document metadata in its output.
+type MetadataSetter interface {
+	Canvas
+
+	// SetMetadata sets the metadata that is
+	// recorded when the canvas is written.
+	SetMetadata(Metadata)
+}
+
 // Initialize sets all of the canvas's values to their
 // initial values.
 func Initialize(c Canvas) {
@@ -128,6 +187,28 @@ func (p *Path) Arc(pt Point, rad Length, s, a float64) {
 	})
 }
 
+// QuadTo adds a quadratic Bézier curve to the path,
+// from the current location to pt, given by the
+// control point p1.
+func (p *Path) QuadTo(p1, pt Point) {
+	*p = append(*p, PathComp{
+		Type:    QuadComp,
+		Pos:     pt,
+		Control: [2]Point{p1},
+	})
+}
+
+// CubeTo adds a cubic Bézier curve to the path,
+// from the current location to pt, given by the
+// control points p1 and p2.
+func (p *Path) CubeTo(p1, p2, pt Point) {
+	*p = append(*p, PathComp{
+		Type:    CubeComp,
+		Pos:     pt,
+		Control: [2]Point{p1, p2},
+	})
+}
+
 // Close closes the path by connecting the current
 // location to the start location with a line.
 func (p *Path) Close() {
@@ -141,6 +222,8 @@ const (
 	LineComp
 	ArcComp
 	CloseComp
+	QuadComp
+	CubeComp
 )
 
 // A PathComp is a component of a path structure.
@@ -152,9 +235,9 @@ type PathComp struct {
 	Type int
 
 	// The Pos field is used as the destination
-	// of a MoveComp or LineComp and is the center
-	// point of an ArcComp.  It is not used in
-	// the CloseComp.
+	// of a MoveComp, LineComp, QuadComp or CubeComp and is
+	// the center point of an ArcComp.  It is not
+	// used in the CloseComp.
 	Pos Point
 
 	// Radius is only used for ArcComps, it is
@@ -166,4 +249,11 @@ type PathComp struct {
 	// the arc around the circle.  The units of the
 	// angle are radians.
 	Start, Angle float64
+
+	// Control is only used for QuadComps and
+	// CubeComps, it holds the control points of
+	// the curve.  A QuadComp, a quadratic Bézier
+	// curve, uses only the first control point and
+	// a CubeComp, a cubic Bézier curve, uses both.
+	Control [2]Point
 }
@@ -26,6 +26,11 @@ type Canvas struct {
 	stack []context
 	w, h  vg.Length
 	buf   *bytes.Buffer
+
+	// title and created are written to the
+	// document header by WriteTo and WritePSTo.
+	title   string
+	created time.Time
 }
 
 type context struct {
@@ -48,21 +53,13 @@ func New(w, h vg.Length) *Canvas {
 // NewTitle returns a new Canvas with the given title string.
 func NewTitle(w, h vg.Length, title string) *Canvas {
 	c := &Canvas{
-		stack: []context{context{}},
-		w:     w,
-		h:     h,
-		buf:   new(bytes.Buffer),
+		stack:   []context{context{}},
+		w:       w,
+		h:       h,
+		buf:     new(bytes.Buffer),
+		title:   title,
+		created: time.Now(),
 	}
-	c.buf.WriteString("%%!PS-Adobe-3.0 EPSF-3.0\n")
-	c.buf.WriteString("%%Creator gonum.org/v1/plot/vg/vgeps\n")
-	c.buf.WriteString("%%Title: " + title + "\n")
-	c.buf.WriteString(fmt.Sprintf("%%%%BoundingBox: 0 0 %.*g %.*g\n",
-		pr, w.Dots(DPI),
-		pr, h.Dots(DPI)))
-	c.buf.WriteString(fmt.Sprintf("%%%%CreationDate: %s\n", time.Now()))
-	c.buf.WriteString("%%Orientation: Portrait\n")
-	c.buf.WriteString("%%EndComments\n")
-	c.buf.WriteString("\n")
 	vg.Initialize(c)
 	return c
 }
@@ -152,14 +149,27 @@ func (e *Canvas) Fill(path vg.Path) {
 	e.buf.WriteString("fill\n")
 }
 
+// Clip implements the vg.Clipper interface.
+func (e *Canvas) Clip(path vg.Path) {
+	e.trace(path)
+	e.buf.WriteString("clip\nnewpath\n")
+}
+
 func (e *Canvas) trace(path vg.Path) {
 	e.buf.WriteString("newpath\n")
+	// start and cur are the start of the current
+	// subpath and the current point, which are
+	// needed to convert quadratic curves to cubic
+	// curves.
+	var start, cur vg.Point
 	for _, comp := range path {
 		switch comp.Type {
 		case vg.MoveComp:
 			fmt.Fprintf(e.buf, "%.*g %.*g moveto\n", pr, comp.Pos.X, pr, comp.Pos.Y)
+			start, cur = comp.Pos, comp.Pos
 		case vg.LineComp:
 			fmt.Fprintf(e.buf, "%.*g %.*g lineto\n", pr, comp.Pos.X, pr, comp.Pos.Y)
+			cur = comp.Pos
 		case vg.ArcComp:
 			end := comp.Start + comp.Angle
 			arcOp := "arc"
@@ -169,8 +179,25 @@ func (e *Canvas) trace(path vg.Path) {
 			fmt.Fprintf(e.buf, "%.*g %.*g %.*g %.*g %.*g %s\n", pr, comp.Pos.X, pr, comp.Pos.Y,
 				pr, comp.Radius, pr, comp.Start*180/math.Pi, pr,
 				end*180/math.Pi, arcOp)
+			cur = comp.Pos.Add(vg.Point{
+				X: comp.Radius * vg.Length(math.Cos(end)),
+				Y: comp.Radius * vg.Length(math.Sin(end)),
+			})
+		case vg.QuadComp, vg.CubeComp:
+			p1, p2 := comp.Control[0], comp.Control[1]
+			if comp.Type == vg.QuadComp {
+				// PostScript only has cubic curves, so the
+				// quadratic curve is elevated to a cubic.
+				q := comp.Control[0]
+				p1 = cur.Add(q.Sub(cur).Scale(2.0 / 3))
+				p2 = comp.Pos.Add(q.Sub(comp.Pos).Scale(2.0 / 3))
+			}
+			fmt.Fprintf(e.buf, "%.*g %.*g %.*g %.*g %.*g %.*g curveto\n",
+				pr, p1.X, pr, p1.Y, pr, p2.X, pr, p2.Y, pr, comp.Pos.X, pr, comp.Pos.Y)
+			cur = comp.Pos
 		case vg.CloseComp:
 			e.buf.WriteString("closepath\n")
+			cur = start
 		default:
 			panic(fmt.Sprintf("Unknown path component type: %d\n", comp.Type))
 		}
@@ -194,17 +221,83 @@ func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 	panic("vgeps: DrawImage not implemented")
 }
 
-// WriteTo writes the canvas to an io.Writer.
+// WriteTo writes the canvas to an io.Writer
+// as encapsulated PostScript.
 func (e *Canvas) WriteTo(w io.Writer) (int64, error) {
+	var hdr bytes.Buffer
+	hdr.WriteString("%%!PS-Adobe-3.0 EPSF-3.0\n")
+	hdr.WriteString("%%Creator gonum.org/v1/plot/vg/vgeps\n")
+	hdr.WriteString("%%Title: " + e.title + "\n")
+	fmt.Fprintf(&hdr, "%%%%BoundingBox: 0 0 %.*g %.*g\n",
+		pr, e.w.Dots(DPI),
+		pr, e.h.Dots(DPI))
+	fmt.Fprintf(&hdr, "%%%%CreationDate: %s\n", e.created)
+	hdr.WriteString("%%Orientation: Portrait\n")
+	hdr.WriteString("%%EndComments\n")
+	hdr.WriteString("\n")
+	return e.write(w, hdr.Bytes(), "showpage\n")
+}
+
+// WritePSTo writes the canvas to an io.Writer as a
+// single page PostScript document.  Unlike EPS output,
+// which is meant to be placed within another document,
+// the page is sized to the canvas, and the bounding box
+// is rounded out to whole points as the document
+// structuring conventions require, with the exact extent
+// given by the HiResBoundingBox comment.
+func (e *Canvas) WritePSTo(w io.Writer) (int64, error) {
+	width, height := e.w.Dots(DPI), e.h.Dots(DPI)
+	var hdr bytes.Buffer
+	hdr.WriteString("%!PS-Adobe-3.0\n")
+	hdr.WriteString("%%Creator gonum.org/v1/plot/vg/vgeps\n")
+	hdr.WriteString("%%Title: " + e.title + "\n")
+	fmt.Fprintf(&hdr, "%%%%BoundingBox: 0 0 %d %d\n",
+		int(math.Ceil(width)), int(math.Ceil(height)))
+	fmt.Fprintf(&hdr, "%%%%HiResBoundingBox: 0 0 %.*g %.*g\n",
+		pr, width, pr, height)
+	fmt.Fprintf(&hdr, "%%%%CreationDate: %s\n", e.created)
+	hdr.WriteString("%%Orientation: Portrait\n")
+	hdr.WriteString("%%Pages: 1\n")
+	hdr.WriteString("%%EndComments\n")
+	hdr.WriteString("%%BeginSetup\n")
+	fmt.Fprintf(&hdr, "<< /PageSize [%.*g %.*g] >> setpagedevice\n",
+		pr, width, pr, height)
+	hdr.WriteString("%%EndSetup\n")
+	hdr.WriteString("%%Page: 1 1\n")
+	hdr.WriteString("\n")
+	return e.write(w, hdr.Bytes(), "showpage\n%%EOF\n")
+}
+
+// write writes the header, the drawing
+// and the trailer to w.
+func (e *Canvas) write(w io.Writer, hdr []byte, trailer string) (int64, error) {
 	b := bufio.NewWriter(w)
-	n, err := e.buf.WriteTo(b)
+	m, err := b.Write(hdr)
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+	k, err := e.buf.WriteTo(b)
+	n += k
 	if err != nil {
 		return n, err
 	}
-	m, err := fmt.Fprintln(b, "showpage")
+	m, err = b.WriteString(trailer)
 	n += int64(m)
 	if err != nil {
 		return n, err
 	}
 	return n, b.Flush()
 }
+
+// PSCanvas implements the vg.CanvasWriterTo interface,
+// writing the canvas as PostScript rather than EPS.
+type PSCanvas struct {
+	*Canvas
+}
+
+// WriteTo implements the io.WriterTo interface, writing
+// the canvas as a PostScript document.
+func (c PSCanvas) WriteTo(w io.Writer) (int64, error) {
+	return c.WritePSTo(w)
+}
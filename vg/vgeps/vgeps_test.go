@@ -0,0 +1,69 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgeps
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+)
+
+func TestWritePSTo(t *testing.T) {
+	c := New(vg.Points(100.5), vg.Points(50))
+	var p vg.Path
+	p.Move(vg.Point{X: 10, Y: 10})
+	p.Line(vg.Point{X: 20, Y: 20})
+	c.Stroke(p)
+
+	var buf bytes.Buffer
+	n, err := PSCanvas{c}.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("unexpected byte count: got:%d want:%d", n, buf.Len())
+	}
+	ps := buf.String()
+	if !strings.HasPrefix(ps, "%!PS-Adobe-3.0\n") {
+		t.Errorf("PostScript output does not begin with a PostScript header:\n%s", ps)
+	}
+	for _, want := range []string{
+		"%%BoundingBox: 0 0 101 50\n",
+		"%%HiResBoundingBox: 0 0 100.5 50\n",
+		"<< /PageSize [100.5 50] >> setpagedevice\n",
+		"%%Page: 1 1\n",
+		"10 10 moveto\n20 20 lineto\nstroke\n",
+	} {
+		if !strings.Contains(ps, want) {
+			t.Errorf("PostScript output does not contain %q:\n%s", want, ps)
+		}
+	}
+	if !strings.HasSuffix(ps, "showpage\n%%EOF\n") {
+		t.Errorf("PostScript output does not end with a trailer:\n%s", ps)
+	}
+	if strings.Contains(ps, "EPSF") {
+		t.Errorf("PostScript output has an EPS header:\n%s", ps)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	c := New(vg.Points(100), vg.Points(50))
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	eps := buf.String()
+	if !strings.Contains(eps, "EPSF-3.0\n") {
+		t.Errorf("EPS output does not have an EPS header:\n%s", eps)
+	}
+	if !strings.Contains(eps, "%%BoundingBox: 0 0 100 50\n") {
+		t.Errorf("EPS output has an unexpected bounding box:\n%s", eps)
+	}
+	if strings.Contains(eps, "setpagedevice") {
+		t.Errorf("EPS output sets the page size:\n%s", eps)
+	}
+}
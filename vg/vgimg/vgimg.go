@@ -9,6 +9,7 @@ package vgimg // import "gonum.org/v1/plot/vg/vgimg"
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -16,10 +17,12 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"math"
 	"sync"
 
 	"golang.org/x/image/tiff"
 
+	"github.com/golang/freetype/raster"
 	"github.com/llgcode/draw2d"
 	"github.com/llgcode/draw2d/draw2dimg"
 
@@ -37,8 +40,49 @@ type Canvas struct {
 	// dpi is the number of dots per inch for this canvas.
 	dpi int
 
+	// ydpi is the number of vertical dots per inch
+	// for this canvas if its pixels are not square,
+	// or zero if it is the same as dpi.
+	ydpi int
+
 	// width is the current line width.
 	width vg.Length
+
+	// backgroundColor is the color the canvas is
+	// filled with when it is created.
+	backgroundColor color.Color
+
+	// clips is the stack of clipping regions
+	// in effect.
+	clips []clip
+
+	// aliasedText specifies that text is drawn
+	// without anti-aliasing.
+	aliasedText bool
+
+	// snapLines specifies that horizontal and
+	// vertical lines are snapped to the pixel grid.
+	snapLines bool
+}
+
+// clip is a clipping region.  While it is in
+// effect, drawing is to an offscreen image that
+// is composited onto the image beneath it through
+// the clip mask when the clip is removed.
+type clip struct {
+	// depth is the depth of the state stack
+	// at which the clip was set.
+	depth int
+
+	// img and gc are the image and context
+	// that were being drawn to before the clip
+	// was set.
+	img draw.Image
+	gc  draw2d.GraphicContext
+
+	// mask is the clipping region, including
+	// that of any enclosing clip.
+	mask *image.Alpha
 }
 
 const (
@@ -59,14 +103,17 @@ func New(w, h vg.Length) *Canvas {
 
 // NewWith returns a new image canvas created according to the specified
 // options. The currently accepted options are UseWH,
-// UseDPI, UseImage, and UseImageWithContext.
+// UseDPI, UseXYDPI, UseImage, UseImageWithContext, UseBackgroundColor,
+// UseAliasedText and UseSnapLines.
 // Each of the options specifies the size of the canvas (UseWH, UseImage),
-// the resolution of the canvas (UseDPI), or both (useImageWithContext).
+// the resolution of the canvas (UseDPI, UseXYDPI), or both (useImageWithContext).
 // If size or resolution are not specified, defaults are used.
+// The canvas is filled with white unless UseBackgroundColor specifies
+// another color.
 // It panics if size and resolution are overspecified (i.e., too many options are
 // passed).
 func NewWith(o ...option) *Canvas {
-	c := new(Canvas)
+	c := &Canvas{backgroundColor: color.White}
 	var g uint32
 	for _, opt := range o {
 		f := opt(c)
@@ -78,6 +125,10 @@ func NewWith(o ...option) *Canvas {
 	if c.dpi == 0 {
 		c.dpi = DefaultDPI
 	}
+	ydpi := c.ydpi
+	if ydpi == 0 {
+		ydpi = c.dpi
+	}
 	if c.w == 0 { // h should also == 0.
 		if c.img == nil {
 			c.w = DefaultWidth
@@ -86,22 +137,29 @@ func NewWith(o ...option) *Canvas {
 			w := float64(c.img.Bounds().Max.X - c.img.Bounds().Min.X)
 			h := float64(c.img.Bounds().Max.Y - c.img.Bounds().Min.Y)
 			c.w = vg.Length(w/float64(c.dpi)) * vg.Inch
-			c.h = vg.Length(h/float64(c.dpi)) * vg.Inch
+			c.h = vg.Length(h/float64(ydpi)) * vg.Inch
 		}
 	}
 	if c.img == nil {
 		w := c.w / vg.Inch * vg.Length(c.dpi)
-		h := c.h / vg.Inch * vg.Length(c.dpi)
+		h := c.h / vg.Inch * vg.Length(ydpi)
 		c.img = draw.Image(image.NewRGBA(image.Rect(0, 0, int(w+0.5), int(h+0.5))))
 	}
 	if c.gc == nil {
 		h := float64(c.img.Bounds().Max.Y - c.img.Bounds().Min.Y)
 		c.gc = draw2dimg.NewGraphicContext(c.img)
 		c.gc.SetDPI(c.dpi)
-		c.gc.Scale(1, -1)
-		c.gc.Translate(0, -h)
+		// Drawing is done in horizontal dots, which
+		// are scaled to vertical dots for non-square
+		// pixels.
+		r := float64(ydpi) / float64(c.dpi)
+		c.gc.Scale(1, -r)
+		c.gc.Translate(0, -h/r)
 	}
-	draw.Draw(c.img, c.img.Bounds(), image.White, image.ZP, draw.Src)
+	// Fill using the nonzero winding rule,
+	// as the other backends do.
+	c.gc.SetFillRule(draw2d.FillRuleWinding)
+	draw.Draw(c.img, c.img.Bounds(), image.NewUniform(c.backgroundColor), image.ZP, draw.Src)
 	c.color = []color.Color{color.Black}
 	vg.Initialize(c)
 	return c
@@ -141,6 +199,23 @@ func UseDPI(dpi int) option {
 	}
 }
 
+// UseXYDPI sets the horizontal and vertical dots per inch
+// of a canvas with non-square pixels, so that the image
+// is scaled independently in each direction.  It should
+// only be used as an option argument when initializing a
+// new canvas, instead of UseDPI.  The DPI method of the
+// canvas returns the horizontal resolution.
+func UseXYDPI(xdpi, ydpi int) option {
+	if xdpi <= 0 || ydpi <= 0 {
+		panic("DPI must be > 0.")
+	}
+	return func(c *Canvas) uint32 {
+		c.dpi = xdpi
+		c.ydpi = ydpi
+		return setsDPI
+	}
+}
+
 // UseImage specifies an image to create
 // the canvas from. The
 // minimum point of the given image
@@ -165,6 +240,46 @@ func UseImageWithContext(img draw.Image, gc draw2d.GraphicContext) option {
 	}
 }
 
+// UseBackgroundColor specifies the color the canvas
+// is filled with when it is created.  A nil color
+// leaves the canvas transparent.
+func UseBackgroundColor(c color.Color) option {
+	return func(cnv *Canvas) uint32 {
+		if c == nil {
+			c = color.Transparent
+		}
+		cnv.backgroundColor = c
+		return 0
+	}
+}
+
+// UseAliasedText specifies that text is drawn without
+// anti-aliasing, so that each pixel is either fully
+// covered by a glyph or left untouched.  This can make
+// small text crisper at low resolutions.  Shapes are
+// still drawn anti-aliased.  UseAliasedText only has an
+// effect when the canvas draws to an *image.RGBA.
+func UseAliasedText() option {
+	return func(c *Canvas) uint32 {
+		c.aliasedText = true
+		return 0
+	}
+}
+
+// UseSnapLines specifies that the horizontal and vertical
+// segments of stroked lines are moved by less than a pixel
+// so that they are centered on the pixel grid, and so are
+// drawn crisply rather than blurred across two rows or
+// columns of pixels.  Lines that are part of paths with
+// arcs or curves, or that are drawn while the canvas is
+// rotated or scaled, are not snapped.
+func UseSnapLines() option {
+	return func(c *Canvas) uint32 {
+		c.snapLines = true
+		return 0
+	}
+}
+
 // Image returns the image the canvas is drawing to.
 //
 // The dimensions of the returned image must not be modified.
@@ -216,40 +331,118 @@ func (c *Canvas) Push() {
 }
 
 func (c *Canvas) Pop() {
+	for n := len(c.clips); n > 0 && c.clips[n-1].depth == len(c.color); n-- {
+		cl := c.clips[n-1]
+		b := c.img.Bounds()
+		draw.DrawMask(cl.img, b, c.img, b.Min, cl.mask, b.Min, draw.Over)
+		c.img, c.gc = cl.img, cl.gc
+		c.clips = c.clips[:n-1]
+	}
 	c.color = c.color[:len(c.color)-1]
 	c.gc.Restore()
 }
 
+// Clip implements the vg.Clipper interface.
+func (c *Canvas) Clip(p vg.Path) {
+	if len(c.color) < 2 {
+		panic("vgimg: Clip called without Push")
+	}
+	b := c.img.Bounds()
+
+	// Rasterize the clipping path to make the mask.
+	rgba := image.NewRGBA(b)
+	mgc := c.newContext(rgba)
+	mgc.SetFillColor(color.Opaque)
+	c.outline(mgc, p)
+	mgc.Fill()
+	mask := image.NewAlpha(b)
+	for i := range mask.Pix {
+		mask.Pix[i] = rgba.Pix[4*i+3]
+	}
+	if n := len(c.clips); n > 0 {
+		outer := c.clips[n-1].mask
+		for i, a := range outer.Pix {
+			mask.Pix[i] = uint8(uint16(mask.Pix[i]) * uint16(a) / 0xff)
+		}
+	}
+
+	// Draw to a transparent offscreen image until
+	// the clip is removed.
+	img := image.NewRGBA(b)
+	c.clips = append(c.clips, clip{depth: len(c.color), img: c.img, gc: c.gc, mask: mask})
+	c.img, c.gc = img, c.newContext(img)
+}
+
+// newContext returns a graphic context drawing to img
+// with the same state as the canvas' current context.
+func (c *Canvas) newContext(img *image.RGBA) draw2d.GraphicContext {
+	return c.newContextWithPainter(img, raster.NewRGBAPainter(img))
+}
+
+// newContextWithPainter returns a graphic context drawing
+// to img through painter, with the same state as the
+// canvas' current context.
+func (c *Canvas) newContextWithPainter(img *image.RGBA, painter draw2dimg.Painter) draw2d.GraphicContext {
+	gc := draw2dimg.NewGraphicContextWithPainter(img, painter)
+	gc.SetDPI(c.gc.GetDPI())
+	if cur, ok := c.gc.(*draw2dimg.GraphicContext); ok {
+		st := *cur.Current
+		st.Path = st.Path.Copy()
+		st.Previous = nil
+		*gc.Current = st
+		return gc
+	}
+	gc.SetMatrixTransform(c.gc.GetMatrixTransform())
+	gc.SetFillRule(draw2d.FillRuleWinding)
+	gc.SetLineWidth(c.width.Dots(c.DPI()))
+	gc.SetFillColor(c.color[len(c.color)-1])
+	gc.SetStrokeColor(c.color[len(c.color)-1])
+	return gc
+}
+
 func (c *Canvas) Stroke(p vg.Path) {
 	if c.width <= 0 {
 		return
 	}
-	c.outline(p)
+	if c.snapLines && c.snappable(p) {
+		c.snappedOutline(c.gc, p)
+	} else {
+		c.outline(c.gc, p)
+	}
 	c.gc.Stroke()
 }
 
 func (c *Canvas) Fill(p vg.Path) {
-	c.outline(p)
+	c.outline(c.gc, p)
 	c.gc.Fill()
 }
 
-func (c *Canvas) outline(p vg.Path) {
-	c.gc.BeginPath()
+func (c *Canvas) outline(gc draw2d.GraphicContext, p vg.Path) {
+	gc.BeginPath()
 	for _, comp := range p {
 		switch comp.Type {
 		case vg.MoveComp:
-			c.gc.MoveTo(comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()))
+			gc.MoveTo(comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()))
 
 		case vg.LineComp:
-			c.gc.LineTo(comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()))
+			gc.LineTo(comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()))
 
 		case vg.ArcComp:
-			c.gc.ArcTo(comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()),
+			gc.ArcTo(comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()),
 				comp.Radius.Dots(c.DPI()), comp.Radius.Dots(c.DPI()),
 				comp.Start, comp.Angle)
 
+		case vg.QuadComp:
+			gc.QuadCurveTo(comp.Control[0].X.Dots(c.DPI()), comp.Control[0].Y.Dots(c.DPI()),
+				comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()))
+
+		case vg.CubeComp:
+			gc.CubicCurveTo(comp.Control[0].X.Dots(c.DPI()), comp.Control[0].Y.Dots(c.DPI()),
+				comp.Control[1].X.Dots(c.DPI()), comp.Control[1].Y.Dots(c.DPI()),
+				comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()))
+
 		case vg.CloseComp:
-			c.gc.Close()
+			gc.Close()
 
 		default:
 			panic(fmt.Sprintf("Unknown path component: %d", comp.Type))
@@ -257,13 +450,82 @@ func (c *Canvas) outline(p vg.Path) {
 	}
 }
 
+// snappable returns whether the path p can be snapped
+// to the pixel grid.  This is the case if p is made only
+// of straight lines and the current transformation maps
+// pixel centers to pixel centers.
+func (c *Canvas) snappable(p vg.Path) bool {
+	for _, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp, vg.LineComp, vg.CloseComp:
+		default:
+			return false
+		}
+	}
+	tr := c.gc.GetMatrixTransform()
+	return tr[1] == 0 && tr[2] == 0 &&
+		math.Abs(tr[0]) == 1 && math.Abs(tr[3]) == 1 &&
+		tr[4] == math.Trunc(tr[4]) && tr[5] == math.Trunc(tr[5])
+}
+
+// snappedOutline outlines the path p, which must be made
+// only of straight lines, moving the ends of each of its
+// horizontal and vertical segments onto the pixel grid.
+func (c *Canvas) snappedOutline(gc draw2d.GraphicContext, p vg.Path) {
+	dpi := c.DPI()
+
+	// Lines with an odd number of pixels width are
+	// centered on pixel centers and the others are
+	// centered on pixel boundaries.
+	var snap func(float64) float64
+	if w := math.Max(1, math.Floor(c.width.Dots(dpi)+0.5)); math.Mod(w, 2) == 0 {
+		snap = func(v float64) float64 { return math.Floor(v + 0.5) }
+	} else {
+		snap = func(v float64) float64 { return math.Floor(v) + 0.5 }
+	}
+
+	pts := make([][2]float64, len(p))
+	for i, comp := range p {
+		pts[i] = [2]float64{comp.Pos.X.Dots(dpi), comp.Pos.Y.Dots(dpi)}
+	}
+	snapped := make([][2]float64, len(p))
+	copy(snapped, pts)
+	for i := 1; i < len(p); i++ {
+		if p[i].Type != vg.LineComp || p[i-1].Type == vg.CloseComp {
+			continue
+		}
+		for j := 0; j < 2; j++ {
+			if pts[i-1][j] == pts[i][j] {
+				snapped[i-1][j] = snap(pts[i][j])
+				snapped[i][j] = snapped[i-1][j]
+			}
+		}
+	}
+
+	gc.BeginPath()
+	for i, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp:
+			gc.MoveTo(snapped[i][0], snapped[i][1])
+		case vg.LineComp:
+			gc.LineTo(snapped[i][0], snapped[i][1])
+		case vg.CloseComp:
+			gc.Close()
+		}
+	}
+}
+
 func (c *Canvas) DPI() float64 {
 	return float64(c.gc.GetDPI())
 }
 
 func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
-	c.gc.Save()
-	defer c.gc.Restore()
+	gc := c.gc
+	if img, ok := c.img.(*image.RGBA); ok && c.aliasedText {
+		gc = c.newContextWithPainter(img, aliasedPainter{raster.NewRGBAPainter(img)})
+	}
+	gc.Save()
+	defer gc.Restore()
 
 	data := draw2d.FontData{Name: font.Name()}
 	registeredFont.Lock()
@@ -272,11 +534,32 @@ func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
 		registeredFont.m[font.Name()] = true
 	}
 	registeredFont.Unlock()
-	c.gc.SetFontData(data)
-	c.gc.SetFontSize(font.Size.Points())
-	c.gc.Translate(pt.X.Dots(c.DPI()), pt.Y.Dots(c.DPI()))
-	c.gc.Scale(1, -1)
-	c.gc.FillString(str)
+	gc.SetFontData(data)
+	gc.SetFontSize(font.Size.Points())
+	gc.Translate(pt.X.Dots(c.DPI()), pt.Y.Dots(c.DPI()))
+	gc.Scale(1, -1)
+	gc.FillString(str)
+}
+
+// aliasedPainter is a painter that paints each
+// pixel of a span either fully or not at all,
+// depending on whether its coverage is at least
+// one half.
+type aliasedPainter struct {
+	*raster.RGBAPainter
+}
+
+// Paint implements the raster.Painter interface.
+func (p aliasedPainter) Paint(ss []raster.Span, done bool) {
+	aliased := ss[:0]
+	for _, s := range ss {
+		if s.Alpha < 0x8000 {
+			continue
+		}
+		s.Alpha = 0xffff
+		aliased = append(aliased, s)
+	}
+	p.RGBAPainter.Paint(aliased, done)
 }
 
 // DrawImage implements the vg.Canvas.DrawImage method.
@@ -370,3 +653,65 @@ func (c TiffCanvas) WriteTo(w io.Writer) (int64, error) {
 	err := b.Flush()
 	return wc.n, err
 }
+
+// DefaultWebpQuality is the default quality used when
+// writing lossy WebP images.
+const DefaultWebpQuality = 75
+
+// WebpEncoder is a function that writes img to w as a WebP
+// image, using lossy encoding with the given quality in the
+// range [0, 100] or lossless encoding.
+type WebpEncoder func(w io.Writer, img image.Image, quality float32, lossless bool) error
+
+// A WebpCanvas is an image canvas with a WriteTo method that
+// writes a WebP image.
+//
+// The vgimg package does not provide a WebP encoder, so Encode
+// must be set to a function wrapping one, for example that of
+// github.com/chai2010/webp.
+//
+// WebP is not one of the built-in formats of draw.NewFormattedCanvas,
+// so to save plots with plot.Plot.Save, register the format with
+// draw.RegisterFormat, choosing the quality in the FormatFunc:
+//
+//  draw.RegisterFormat("webp", "image/webp", func(w, h vg.Length, bg color.Color) vg.CanvasWriterTo {
+//  	return vgimg.WebpCanvas{
+//  		Canvas:  vgimg.NewWith(vgimg.UseWH(w, h), vgimg.UseBackgroundColor(bg)),
+//  		Encode:  encode,
+//  		Quality: 90,
+//  	}
+//  })
+type WebpCanvas struct {
+	*Canvas
+
+	// Encode is the function used to encode the image.
+	Encode WebpEncoder
+
+	// Quality is the quality of lossy encoding in the
+	// range [0, 100].  If Quality is zero then
+	// DefaultWebpQuality is used.
+	Quality float32
+
+	// Lossless specifies that the image is written
+	// using lossless encoding.  Quality is ignored
+	// for lossless encoding.
+	Lossless bool
+}
+
+// WriteTo implements the io.WriterTo interface, writing a WebP image.
+func (c WebpCanvas) WriteTo(w io.Writer) (int64, error) {
+	if c.Encode == nil {
+		return 0, errors.New("vgimg: no WebP encoder")
+	}
+	q := c.Quality
+	if q == 0 {
+		q = DefaultWebpQuality
+	}
+	wc := writerCounter{Writer: w}
+	b := bufio.NewWriter(&wc)
+	if err := c.Encode(b, c.img, q, c.Lossless); err != nil {
+		return wc.n, err
+	}
+	err := b.Flush()
+	return wc.n, err
+}
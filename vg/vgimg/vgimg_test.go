@@ -2,6 +2,10 @@ package vgimg_test
 
 import (
 	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
@@ -66,3 +70,259 @@ func TestConcurrentInit(t *testing.T) {
 	}()
 	wg.Wait()
 }
+
+func TestWebpCanvas(t *testing.T) {
+	if _, err := (vgimg.WebpCanvas{Canvas: vgimg.New(vg.Inch, vg.Inch)}).WriteTo(ioutil.Discard); err == nil {
+		t.Error("expected error writing image without an encoder")
+	}
+
+	for _, test := range []struct {
+		quality  float32
+		lossless bool
+		want     float32
+	}{
+		{quality: 0, want: vgimg.DefaultWebpQuality},
+		{quality: 90, want: 90},
+		{quality: 0, lossless: true, want: vgimg.DefaultWebpQuality},
+	} {
+		var (
+			gotQuality  float32
+			gotLossless bool
+		)
+		c := vgimg.WebpCanvas{
+			Canvas:   vgimg.New(vg.Inch, vg.Inch/2),
+			Quality:  test.quality,
+			Lossless: test.lossless,
+			Encode: func(w io.Writer, img image.Image, quality float32, lossless bool) error {
+				gotQuality, gotLossless = quality, lossless
+				return png.Encode(w, img)
+			},
+		}
+		var buf bytes.Buffer
+		if _, err := c.WriteTo(&buf); err != nil {
+			t.Fatalf("unexpected error writing image: %v", err)
+		}
+		if gotQuality != test.want || gotLossless != test.lossless {
+			t.Errorf("unexpected encoder arguments for quality=%v lossless=%t: got:(%v, %t) want:(%v, %t)",
+				test.quality, test.lossless, gotQuality, gotLossless, test.want, test.lossless)
+		}
+		cfg, err := png.DecodeConfig(&buf)
+		if err != nil {
+			t.Fatalf("unexpected error decoding image: %v", err)
+		}
+		if cfg.Width != vgimg.DefaultDPI || cfg.Height != vgimg.DefaultDPI/2 {
+			t.Errorf("unexpected image size: got:%dx%d want:%dx%d",
+				cfg.Width, cfg.Height, vgimg.DefaultDPI, vgimg.DefaultDPI/2)
+		}
+	}
+}
+
+func TestClip(t *testing.T) {
+	c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72))
+	red := color.NRGBA{R: 255, A: 255}
+
+	var square vg.Path
+	square.Move(vg.Point{X: 20, Y: 20})
+	square.Line(vg.Point{X: 60, Y: 20})
+	square.Line(vg.Point{X: 60, Y: 60})
+	square.Line(vg.Point{X: 20, Y: 60})
+	square.Close()
+	var whole vg.Path
+	whole.Move(vg.Point{X: 0, Y: 0})
+	whole.Line(vg.Point{X: 100, Y: 0})
+	whole.Line(vg.Point{X: 100, Y: 100})
+	whole.Line(vg.Point{X: 0, Y: 100})
+	whole.Close()
+
+	c.Push()
+	c.Clip(square)
+	c.SetColor(red)
+	c.Fill(whole)
+	c.Pop()
+
+	img := c.Image()
+	for _, test := range []struct {
+		x, y int
+		want color.Color
+	}{
+		// Image y coordinates increase downwards.
+		{x: 40, y: 60, want: color.RGBA{R: 255, A: 255}},
+		{x: 10, y: 10, want: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+		{x: 70, y: 60, want: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+		{x: 40, y: 90, want: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+	} {
+		if got := img.At(test.x, test.y); got != test.want {
+			t.Errorf("unexpected color at (%d, %d): got:%v want:%v", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+func TestNestedClip(t *testing.T) {
+	c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72))
+
+	square := func(min, max vg.Length) vg.Path {
+		var p vg.Path
+		p.Move(vg.Point{X: min, Y: min})
+		p.Line(vg.Point{X: max, Y: min})
+		p.Line(vg.Point{X: max, Y: max})
+		p.Line(vg.Point{X: min, Y: max})
+		p.Close()
+		return p
+	}
+
+	// Clip twice within a single Push; Pop
+	// must remove both clips.
+	c.Push()
+	c.Clip(square(20, 60))
+	c.Clip(square(40, 80))
+	c.SetColor(color.NRGBA{R: 255, A: 255})
+	c.Fill(square(0, 100))
+	c.Pop()
+
+	img := c.Image()
+	for _, test := range []struct {
+		x, y int
+		want color.Color
+	}{
+		// Image y coordinates increase downwards.
+		{x: 50, y: 50, want: color.RGBA{R: 255, A: 255}},
+		{x: 30, y: 70, want: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+		{x: 70, y: 30, want: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+		{x: 10, y: 90, want: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+	} {
+		if got := img.At(test.x, test.y); got != test.want {
+			t.Errorf("unexpected color at (%d, %d): got:%v want:%v", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+func TestFillRule(t *testing.T) {
+	c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72))
+
+	// Two overlapping squares with the same winding
+	// order; the nonzero winding rule fills their
+	// intersection.
+	var p vg.Path
+	for _, off := range []vg.Length{10, 40} {
+		p.Move(vg.Point{X: off, Y: off})
+		p.Line(vg.Point{X: off + 50, Y: off})
+		p.Line(vg.Point{X: off + 50, Y: off + 50})
+		p.Line(vg.Point{X: off, Y: off + 50})
+		p.Close()
+	}
+	c.SetColor(color.Black)
+	c.Fill(p)
+
+	// (50, 50) is within both squares.
+	want := color.RGBA{A: 255}
+	if got := c.Image().At(50, 50); got != want {
+		t.Errorf("unexpected color of intersection: got:%v want:%v", got, want)
+	}
+}
+
+func TestAliasedText(t *testing.T) {
+	font, err := vg.MakeFont("Times-Roman", 8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	colors := func(aliased bool) int {
+		c := vgimg.NewWith(vgimg.UseWH(60, 20), vgimg.UseDPI(72))
+		if aliased {
+			c = vgimg.NewWith(vgimg.UseWH(60, 20), vgimg.UseDPI(72), vgimg.UseAliasedText())
+		}
+		c.SetColor(color.Black)
+		c.FillString(font, vg.Point{X: 5, Y: 5}, "Tick 1.5")
+
+		seen := make(map[color.Color]bool)
+		img := c.Image()
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				seen[img.At(x, y)] = true
+			}
+		}
+		return len(seen)
+	}
+
+	if n := colors(false); n <= 2 {
+		t.Errorf("expected anti-aliased text to use intermediate colors: got %d colors", n)
+	}
+	if n := colors(true); n != 2 {
+		t.Errorf("unexpected number of colors for aliased text: got:%d want:2", n)
+	}
+}
+
+func TestSnapLines(t *testing.T) {
+	colors := func(snap bool) int {
+		c := vgimg.NewWith(vgimg.UseWH(20, 20), vgimg.UseDPI(72))
+		if snap {
+			c = vgimg.NewWith(vgimg.UseWH(20, 20), vgimg.UseDPI(72), vgimg.UseSnapLines())
+		}
+		c.SetColor(color.Black)
+		c.SetLineWidth(1)
+
+		// The lines lie on pixel boundaries, so
+		// they straddle two rows or columns of
+		// pixels unless they are snapped.
+		var p vg.Path
+		p.Move(vg.Point{X: 2, Y: 10})
+		p.Line(vg.Point{X: 18, Y: 10})
+		p.Move(vg.Point{X: 10, Y: 2})
+		p.Line(vg.Point{X: 10, Y: 18})
+		c.Stroke(p)
+
+		seen := make(map[color.Color]bool)
+		img := c.Image()
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				seen[img.At(x, y)] = true
+			}
+		}
+		return len(seen)
+	}
+
+	if n := colors(false); n <= 2 {
+		t.Errorf("expected unsnapped lines to use intermediate colors: got %d colors", n)
+	}
+	if n := colors(true); n != 2 {
+		t.Errorf("unexpected number of colors for snapped lines: got:%d want:2", n)
+	}
+}
+
+func TestXYDPI(t *testing.T) {
+	c := vgimg.NewWith(vgimg.UseWH(2*vg.Inch, 2*vg.Inch), vgimg.UseXYDPI(40, 20))
+	if got, want := c.Image().Bounds().Size(), image.Pt(80, 40); got != want {
+		t.Fatalf("unexpected image size: got:%v want:%v", got, want)
+	}
+	if w, h := c.Size(); w != 2*vg.Inch || h != 2*vg.Inch {
+		t.Errorf("unexpected canvas size: got:%vx%v want:%vx%v", w, h, 2*vg.Inch, 2*vg.Inch)
+	}
+
+	// A square covering the lower left quarter of
+	// the canvas covers the lower left quarter of
+	// the image.
+	var p vg.Path
+	p.Move(vg.Point{})
+	p.Line(vg.Point{X: vg.Inch})
+	p.Line(vg.Point{X: vg.Inch, Y: vg.Inch})
+	p.Line(vg.Point{Y: vg.Inch})
+	p.Close()
+	c.SetColor(color.Black)
+	c.Fill(p)
+
+	black := color.RGBA{A: 255}
+	for _, test := range []struct {
+		x, y int
+		want color.Color
+	}{
+		{x: 1, y: 39, want: black},
+		{x: 38, y: 21, want: black},
+		{x: 41, y: 39, want: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+		{x: 1, y: 18, want: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+	} {
+		if got := c.Image().At(test.x, test.y); got != test.want {
+			t.Errorf("unexpected color at (%d, %d): got:%v want:%v", test.x, test.y, got, test.want)
+		}
+	}
+}
@@ -19,8 +19,12 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/golang/freetype/truetype"
 	pdf "github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
 
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/fonts"
@@ -44,6 +48,10 @@ type Canvas struct {
 	// The default is to embed fonts.
 	// This makes the PDF file more portable but also larger.
 	embed bool
+
+	// outline specifies that text is drawn as the
+	// filled outlines of its glyphs.
+	outline bool
 }
 
 type context struct {
@@ -86,6 +94,39 @@ func (c *Canvas) EmbedFonts(v bool) bool {
 	return prev
 }
 
+// OutlineText specifies whether the resulting PDF canvas should
+// draw text as the filled outlines of its glyphs rather than as
+// text in a font.  Outlined text is drawn identically by every
+// PDF viewer, and no fonts are written to the PDF file, but the
+// file is larger and the text can not be selected or searched.
+// The default is to draw text in a font.
+// OutlineText returns the previous value before modification.
+func (c *Canvas) OutlineText(v bool) bool {
+	prev := c.outline
+	c.outline = v
+	return prev
+}
+
+// SetMetadata sets the document information of the
+// PDF, implementing the vg.MetadataSetter interface.
+func (c *Canvas) SetMetadata(m vg.Metadata) {
+	if m.Title != "" {
+		c.doc.SetTitle(m.Title, true)
+	}
+	if m.Author != "" {
+		c.doc.SetAuthor(m.Author, true)
+	}
+	if m.Subject != "" {
+		c.doc.SetSubject(m.Subject, true)
+	}
+	if len(m.Keywords) != 0 {
+		c.doc.SetKeywords(strings.Join(m.Keywords, " "), true)
+	}
+	if !m.Created.IsZero() {
+		c.doc.SetCreationDate(m.Created)
+	}
+}
+
 func (c *Canvas) DPI() float64 {
 	return float64(c.dpi)
 }
@@ -158,7 +199,16 @@ func (c *Canvas) Fill(p vg.Path) {
 	c.pdfPath(p, "F")
 }
 
+// Clip implements the vg.Clipper interface.
+func (c *Canvas) Clip(p vg.Path) {
+	c.pdfPath(p, "W n")
+}
+
 func (c *Canvas) FillString(fnt vg.Font, pt vg.Point, str string) {
+	if c.outline {
+		c.fillOutlines(fnt, pt, str)
+		return
+	}
 	c.font(fnt, pt)
 	c.doc.SetFont(fnt.Name(), "", c.unit(fnt.Size))
 
@@ -176,6 +226,97 @@ func (c *Canvas) FillString(fnt vg.Font, pt vg.Point, str string) {
 	c.doc.CellFormat(w, h, str, "", 0, "BL", false, 0, "")
 }
 
+// fillOutlines fills the outlines of the glyphs of str
+// with the baseline of the text starting at pt.
+func (c *Canvas) fillOutlines(fnt vg.Font, pt vg.Point, str string) {
+	ttf := fnt.Font()
+
+	// Glyphs are loaded at a scale of one unit per
+	// font unit, which scale converts to a length.
+	upem := fixed.Int26_6(ttf.FUnitsPerEm())
+	scale := fnt.Size / vg.Length(upem)
+
+	var (
+		p       vg.Path
+		glyph   truetype.GlyphBuf
+		prev    truetype.Index
+		hasPrev bool
+	)
+	x := pt.X
+	for _, r := range str {
+		idx := ttf.Index(r)
+		if hasPrev {
+			x += vg.Length(ttf.Kern(upem, prev, idx)) * scale
+		}
+		err := glyph.Load(ttf, upem, idx, font.HintingNone)
+		if err != nil {
+			log.Panicf("vgpdf: could not load glyph for %q: %v", r, err)
+		}
+		start := 0
+		for _, end := range glyph.Ends {
+			outlineContour(&p, glyph.Points[start:end], vg.Point{X: x, Y: pt.Y}, scale)
+			start = end
+		}
+		x += vg.Length(ttf.HMetric(upem, idx).AdvanceWidth) * scale
+		prev, hasPrev = idx, true
+	}
+	if len(p) != 0 {
+		c.Fill(p)
+	}
+}
+
+// outlineContour adds the closed contour of a TrueType
+// glyph, made of straight lines and quadratic curves, to
+// the path p.  The points of the contour are in font units,
+// which are scaled by scale and offset by off.
+func outlineContour(p *vg.Path, pts []truetype.Point, off vg.Point, scale vg.Length) {
+	if len(pts) == 0 {
+		return
+	}
+	pos := func(q truetype.Point) vg.Point {
+		return vg.Point{X: off.X + vg.Length(q.X)*scale, Y: off.Y + vg.Length(q.Y)*scale}
+	}
+	onCurve := func(q truetype.Point) bool { return q.Flags&0x01 != 0 }
+	mid := func(a, b vg.Point) vg.Point { return a.Add(b).Scale(0.5) }
+
+	// The contour starts at an on-curve point, which
+	// is implied between two off-curve points if the
+	// first and last points are both off the curve.
+	last := len(pts) - 1
+	var start vg.Point
+	switch {
+	case onCurve(pts[0]):
+		start = pos(pts[0])
+		pts = pts[1:]
+	case onCurve(pts[last]):
+		start = pos(pts[last])
+		pts = pts[:last]
+	default:
+		start = mid(pos(pts[0]), pos(pts[last]))
+	}
+
+	p.Move(start)
+	q0, on0 := start, true
+	for _, q := range pts {
+		q1, on1 := pos(q), onCurve(q)
+		switch {
+		case on0 && on1:
+			p.Line(q1)
+		case !on0 && on1:
+			p.QuadTo(q0, q1)
+		case !on0 && !on1:
+			p.QuadTo(q0, mid(q0, q1))
+		}
+		q0, on0 = q1, on1
+	}
+	if on0 {
+		p.Line(start)
+	} else {
+		p.QuadTo(q0, start)
+	}
+	p.Close()
+}
+
 func (c *Canvas) sbounds(fnt vg.Font, txt string) (left, top, right, bottom float64) {
 	_, h := c.doc.GetFontSize()
 	d := c.doc.GetFontDesc("", "")
@@ -250,6 +391,15 @@ func (c *Canvas) pdfPath(path vg.Path, style string) {
 		case vg.ArcComp:
 			// FIXME(sbinet): use c.pdf.ArcTo
 			c.arc(comp, style)
+		case vg.QuadComp:
+			cx, cy := c.pdfPoint(comp.Control[0])
+			x, y := c.pdfPoint(comp.Pos)
+			c.doc.CurveTo(cx, cy, x, y)
+		case vg.CubeComp:
+			cx0, cy0 := c.pdfPoint(comp.Control[0])
+			cx1, cy1 := c.pdfPoint(comp.Control[1])
+			x, y := c.pdfPoint(comp.Pos)
+			c.doc.CurveBezierCubicTo(cx0, cy0, cx1, cy1, x, y)
 		case vg.CloseComp:
 			c.doc.LineTo(xp, yp)
 			c.doc.ClosePath()
@@ -15,6 +15,7 @@ import (
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/internal/cmpimg"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 	"gonum.org/v1/plot/vg/vgpdf"
 )
@@ -116,3 +117,25 @@ func TestEmbedFonts(t *testing.T) {
 		})
 	}
 }
+
+func TestOutlineText(t *testing.T) {
+	for _, outline := range []bool{false, true} {
+		c := vgpdf.New(100, 100)
+		c.OutlineText(outline)
+		fnt, err := vg.MakeFont("Times-Roman", 12)
+		if err != nil {
+			t.Fatalf("could not create font: %v", err)
+		}
+		c.FillString(fnt, vg.Point{X: 10, Y: 10}, "outlined")
+
+		var buf bytes.Buffer
+		_, err = c.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("could not write canvas: %v", err)
+		}
+		hasFont := bytes.Contains(buf.Bytes(), []byte("/Type /Font"))
+		if hasFont == outline {
+			t.Errorf("unexpected font in PDF with outline=%v: got:%v want:%v", outline, hasFont, !outline)
+		}
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/base64"
+	"encoding/xml"
 	"fmt"
 	"image"
 	"image/color"
 	"image/png"
 	"io"
 	"math"
+	"time"
 
 	svgo "github.com/ajstarks/svgo"
 
@@ -36,6 +38,28 @@ type Canvas struct {
 	buf   *bytes.Buffer
 	ht    float64
 	stack []context
+
+	// tag is the metadata to attach to the
+	// next element drawn to the canvas.
+	tag *tag
+
+	// clips is the number of clip paths
+	// defined in the canvas, used to give
+	// each a unique id.
+	clips int
+
+	// head is the length of the opening of the
+	// svg element in buf, after which the metadata
+	// is written.
+	head int
+
+	// meta is the document metadata.
+	meta vg.Metadata
+}
+
+// tag is the metadata attached to an SVG element.
+type tag struct {
+	id, href string
 }
 
 type context struct {
@@ -67,6 +91,7 @@ func New(w, h vg.Length) *Canvas {
 		pr, w/vg.Inch,
 		pr, h/vg.Inch,
 	)
+	c.head = buf.Len()
 
 	// Swap the origin to the bottom left.
 	// This must be matched with a </g> when saving,
@@ -127,23 +152,83 @@ func (c *Canvas) Pop() {
 	c.stack = c.stack[:len(c.stack)-1]
 }
 
+// Tag implements the vg.Tagger interface, attaching metadata
+// to the next element drawn to the canvas by Stroke, Fill,
+// FillString or DrawImage.  If id is not empty the element
+// is given the id attribute, and if href is not empty the
+// element is wrapped in a link to href, making it clickable
+// when the SVG is displayed in a web browser.  A subsequent
+// call to Tag before an element is drawn replaces the
+// pending metadata.
+func (c *Canvas) Tag(id, href string) {
+	c.tag = &tag{id: id, href: href}
+}
+
+// beginTag writes the opening of the link for the pending
+// tag, if any, and returns the attributes to add to the
+// tagged element.
+func (c *Canvas) beginTag() []string {
+	if c.tag == nil {
+		return nil
+	}
+	if c.tag.href != "" {
+		fmt.Fprintf(c.buf, "<a xlink:href=\"%s\">\n", escape(c.tag.href))
+	}
+	if c.tag.id == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf(`id="%s"`, escape(c.tag.id))}
+}
+
+// endTag writes the closing of the link for the
+// pending tag, if any, and clears the tag.
+func (c *Canvas) endTag() {
+	if c.tag != nil && c.tag.href != "" {
+		c.buf.WriteString("</a>\n")
+	}
+	c.tag = nil
+}
+
+// escape returns s escaped for use in an
+// XML attribute value.
+func escape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
 func (c *Canvas) Stroke(path vg.Path) {
 	if c.context().lineWidth.Dots(DPI) <= 0 {
 		return
 	}
+	attrs := c.beginTag()
 	c.svg.Path(c.pathData(path),
-		style(elm("fill", "#000000", "none"),
-			elm("stroke", "none", colorString(c.context().color)),
-			elm("stroke-opacity", "1", opacityString(c.context().color)),
+		append([]string{style(elm("fill", "#000000", "none"),
+			elm("stroke", "none", "%s", colorString(c.context().color)),
+			elm("stroke-opacity", "1", "%s", opacityString(c.context().color)),
 			elm("stroke-width", "1", "%.*g", pr, c.context().lineWidth.Dots(DPI)),
-			elm("stroke-dasharray", "none", dashArrayString(c)),
-			elm("stroke-dashoffset", "0", "%.*g", pr, c.context().dashOffset.Dots(DPI))))
+			elm("stroke-dasharray", "none", "%s", dashArrayString(c)),
+			elm("stroke-dashoffset", "0", "%.*g", pr, c.context().dashOffset.Dots(DPI)))}, attrs...)...)
+	c.endTag()
 }
 
 func (c *Canvas) Fill(path vg.Path) {
+	attrs := c.beginTag()
 	c.svg.Path(c.pathData(path),
-		style(elm("fill", "#000000", colorString(c.context().color)),
-			elm("fill-opacity", "1", opacityString(c.context().color))))
+		append([]string{style(elm("fill", "#000000", "%s", colorString(c.context().color)),
+			elm("fill-opacity", "1", "%s", opacityString(c.context().color)))}, attrs...)...)
+	c.endTag()
+}
+
+// Clip implements the vg.Clipper interface.
+func (c *Canvas) Clip(path vg.Path) {
+	c.clips++
+	id := fmt.Sprintf("clip%d", c.clips)
+	c.svg.ClipPath(`id="` + id + `"`)
+	c.svg.Path(c.pathData(path))
+	c.svg.ClipEnd()
+	c.svg.Group(`clip-path="url(#` + id + `)"`)
+	c.context().gEnds++
 }
 
 func (c *Canvas) pathData(path vg.Path) string {
@@ -171,6 +256,19 @@ func (c *Canvas) pathData(path vg.Path) string {
 			} else {
 				x, y = arc(buf, c, &comp)
 			}
+		case vg.QuadComp:
+			fmt.Fprintf(buf, "Q%.*g,%.*g,%.*g,%.*g",
+				pr, comp.Control[0].X.Dots(DPI), pr, comp.Control[0].Y.Dots(DPI),
+				pr, comp.Pos.X.Dots(DPI), pr, comp.Pos.Y.Dots(DPI))
+			x = comp.Pos.X.Dots(DPI)
+			y = comp.Pos.Y.Dots(DPI)
+		case vg.CubeComp:
+			fmt.Fprintf(buf, "C%.*g,%.*g,%.*g,%.*g,%.*g,%.*g",
+				pr, comp.Control[0].X.Dots(DPI), pr, comp.Control[0].Y.Dots(DPI),
+				pr, comp.Control[1].X.Dots(DPI), pr, comp.Control[1].Y.Dots(DPI),
+				pr, comp.Pos.X.Dots(DPI), pr, comp.Pos.Y.Dots(DPI))
+			x = comp.Pos.X.Dots(DPI)
+			y = comp.Pos.Y.Dots(DPI)
 		case vg.CloseComp:
 			buf.WriteString("Z")
 		default:
@@ -253,12 +351,16 @@ func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
 	}
 	sty := style(fontStr,
 		elm("font-size", "medium", "%.*gpt", pr, font.Size.Points()),
-		elm("fill", "#000000", colorString(c.context().color)))
+		elm("fill", "#000000", "%s", colorString(c.context().color)))
 	if sty != "" {
 		sty = "\n\t" + sty
 	}
+	for _, attr := range c.beginTag() {
+		sty += " " + attr
+	}
 	fmt.Fprintf(c.buf, `<text x="%.*g" y="%.*g" transform="scale(1, -1)"%s>%s</text>`+"\n",
 		pr, pt.X.Dots(DPI), pr, -pt.Y.Dots(DPI), sty, str)
+	c.endTag()
 }
 
 // DrawImage implements the vg.Canvas.DrawImage method.
@@ -277,6 +379,10 @@ func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 		xmin   = min.X.Dots(DPI)
 		ymin   = min.Y.Dots(DPI)
 	)
+	attrs := `transform="scale(1, -1)"` // invert y so image is not upside-down
+	for _, attr := range c.beginTag() {
+		attrs += " " + attr
+	}
 	fmt.Fprintf(
 		c.buf,
 		`<image x="%v" y="%v" width="%v" height="%v" xlink:href="%s" %s />`+"\n",
@@ -285,9 +391,9 @@ func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 		width,
 		height,
 		str,
-		// invert y so image is not upside-down
-		`transform="scale(1, -1)"`,
+		attrs,
 	)
+	c.endTag()
 }
 
 var (
@@ -312,7 +418,19 @@ var (
 // WriteTo writes the canvas to an io.Writer.
 func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 	b := bufio.NewWriter(w)
-	n, err := c.buf.WriteTo(b)
+	m, err := b.Write(c.buf.Next(c.head))
+	n := int64(m)
+	c.head = 0
+	if err != nil {
+		return n, err
+	}
+	m, err = c.writeMetadata(b)
+	n += int64(m)
+	if err != nil {
+		return n, err
+	}
+	k, err := c.buf.WriteTo(b)
+	n += k
 	if err != nil {
 		return n, err
 	}
@@ -328,7 +446,7 @@ func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 		}
 	}
 
-	m, err := fmt.Fprintln(b, "</svg>")
+	m, err = fmt.Fprintln(b, "</svg>")
 	n += int64(m)
 	if err != nil {
 		return n, err
@@ -337,6 +455,60 @@ func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 	return n, b.Flush()
 }
 
+// SetMetadata sets the metadata of the SVG, implementing
+// the vg.MetadataSetter interface.  The title is written
+// as the title element of the document and all of the
+// metadata is written as Dublin Core properties in its
+// metadata element.
+func (c *Canvas) SetMetadata(m vg.Metadata) {
+	c.meta = m
+}
+
+// writeMetadata writes the title and metadata
+// elements for the document metadata, if any.
+func (c *Canvas) writeMetadata(w io.Writer) (int, error) {
+	m := c.meta
+	var props bytes.Buffer
+	prop := func(name, val string) {
+		if val == "" {
+			return
+		}
+		fmt.Fprintf(&props, "\t\t\t<dc:%s>", name)
+		xml.EscapeText(&props, []byte(val))
+		fmt.Fprintf(&props, "</dc:%s>\n", name)
+	}
+	prop("title", m.Title)
+	prop("creator", m.Author)
+	prop("description", m.Subject)
+	for _, k := range m.Keywords {
+		prop("subject", k)
+	}
+	if !m.Created.IsZero() {
+		prop("date", m.Created.Format(time.RFC3339))
+	}
+	if props.Len() == 0 {
+		return 0, nil
+	}
+
+	var buf bytes.Buffer
+	if m.Title != "" {
+		buf.WriteString("<title>")
+		xml.EscapeText(&buf, []byte(m.Title))
+		buf.WriteString("</title>\n")
+	}
+	buf.WriteString(`<metadata>
+	<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+		xmlns:dc="http://purl.org/dc/elements/1.1/">
+		<rdf:Description>
+`)
+	props.WriteTo(&buf)
+	buf.WriteString(`		</rdf:Description>
+	</rdf:RDF>
+</metadata>
+`)
+	return w.Write(buf.Bytes())
+}
+
 // nEnds returns the number of group ends
 // needed before the SVG is saved.
 func (c *Canvas) nEnds() int {
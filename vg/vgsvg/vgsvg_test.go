@@ -0,0 +1,121 @@
+// Copyright ©2018 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgsvg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"gonum.org/v1/plot/vg"
+)
+
+func TestTag(t *testing.T) {
+	c := New(vg.Points(100), vg.Points(100))
+	var p vg.Path
+	p.Move(vg.Point{X: 10, Y: 10})
+	p.Line(vg.Point{X: 20, Y: 20})
+	p.Line(vg.Point{X: 10, Y: 20})
+	p.Close()
+
+	c.Tag("point-1", "https://example.com/?a=1&b=2")
+	c.Fill(p)
+	c.Fill(p)
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+
+	const link = `<a xlink:href="https://example.com/?a=1&amp;b=2">`
+	if n := strings.Count(svg, link); n != 1 {
+		t.Errorf("unexpected number of links: got:%d want:1\n%s", n, svg)
+	}
+	if n := strings.Count(svg, `id="point-1"`); n != 1 {
+		t.Errorf("unexpected number of tagged elements: got:%d want:1\n%s", n, svg)
+	}
+	start := strings.Index(svg, link)
+	end := strings.Index(svg, "</a>")
+	if end < start || !strings.Contains(svg[start:end], `<path`) {
+		t.Errorf("tagged path not wrapped in link:\n%s", svg)
+	}
+}
+
+func TestClip(t *testing.T) {
+	c := New(vg.Points(100), vg.Points(100))
+	var p vg.Path
+	p.Move(vg.Point{X: 10, Y: 10})
+	p.Line(vg.Point{X: 20, Y: 20})
+	p.Line(vg.Point{X: 10, Y: 20})
+	p.Close()
+
+	c.Push()
+	c.Clip(p)
+	c.Fill(p)
+	c.Pop()
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+
+	if n := strings.Count(svg, `<clipPath id="clip1"`); n != 1 {
+		t.Errorf("unexpected number of clip paths: got:%d want:1\n%s", n, svg)
+	}
+	start := strings.Index(svg, `<g clip-path="url(#clip1)"`)
+	if start < 0 {
+		t.Fatalf("clipped group not found:\n%s", svg)
+	}
+	end := strings.Index(svg[start:], "</g>")
+	if end < 0 || !strings.Contains(svg[start:start+end], `<path`) {
+		t.Errorf("fill not within clipped group:\n%s", svg)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	c := New(vg.Points(100), vg.Points(100))
+	c.SetMetadata(vg.Metadata{
+		Title:    "Results <draft>",
+		Author:   "Gopher",
+		Keywords: []string{"plot", "svg"},
+		Created:  time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	var p vg.Path
+	p.Move(vg.Point{X: 10, Y: 10})
+	p.Line(vg.Point{X: 20, Y: 20})
+	c.Stroke(p)
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+
+	for _, want := range []string{
+		"<title>Results &lt;draft&gt;</title>",
+		"<dc:title>Results &lt;draft&gt;</dc:title>",
+		"<dc:creator>Gopher</dc:creator>",
+		"<dc:subject>plot</dc:subject>",
+		"<dc:subject>svg</dc:subject>",
+		"<dc:date>2018-01-02T03:04:05Z</dc:date>",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("missing %q in output:\n%s", want, svg)
+		}
+	}
+	if strings.Contains(svg, "dc:description") {
+		t.Errorf("unexpected empty subject in output:\n%s", svg)
+	}
+	if title, path := strings.Index(svg, "<title>"), strings.Index(svg, "<path"); title > path {
+		t.Errorf("title not written before drawing:\n%s", svg)
+	}
+	if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+		t.Errorf("invalid XML: %v", err)
+	}
+}
@@ -161,6 +161,13 @@ func (c *Canvas) Fill(p vg.Path) {
 	c.wtex("")
 }
 
+// Clip implements the vg.Clipper.Clip method.
+func (c *Canvas) Clip(p vg.Path) {
+	c.wpath(p)
+	c.wtex(`\pgfusepath{clip}`)
+	c.wtex("")
+}
+
 // FillString implements the vg.Canvas.FillString method.
 func (c *Canvas) FillString(f vg.Font, pt vg.Point, text string) {
 	c.wcolor()
@@ -258,6 +265,12 @@ func (c *Canvas) wpath(p vg.Path) {
 			angle := comp.Angle * degPerRadian
 			r := comp.Radius
 			c.wtex(`\pgfpatharc{%g}{%g}{%gpt}`, start, angle, r)
+		case vg.QuadComp:
+			c.wtex(`\pgfpathquadraticcurveto{\pgfpoint{%gpt}{%gpt}}{\pgfpoint{%gpt}{%gpt}}`,
+				comp.Control[0].X, comp.Control[0].Y, comp.Pos.X, comp.Pos.Y)
+		case vg.CubeComp:
+			c.wtex(`\pgfpathcurveto{\pgfpoint{%gpt}{%gpt}}{\pgfpoint{%gpt}{%gpt}}{\pgfpoint{%gpt}{%gpt}}`,
+				comp.Control[0].X, comp.Control[0].Y, comp.Control[1].X, comp.Control[1].Y, comp.Pos.X, comp.Pos.Y)
 		case vg.CloseComp:
 			c.wtex("%% path-close")
 		default: